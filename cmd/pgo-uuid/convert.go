@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// move time based uuid to the `version` layout, v1 or v6
+func reorder(u pgo.UUID, version string) (pgo.UUID, error) {
+	switch {
+	case version == "":
+		return u, nil
+	case version == "v6" && u.Version() == 1:
+		return pgo.UUIDv1toV6(u)
+	case version == "v1" && u.Version() == 6:
+		return pgo.UUIDv6toV1(u)
+	case version == "v6" && u.Version() == 6, version == "v1" && u.Version() == 1:
+		return u, nil
+	}
+	return u, fmt.Errorf("can't reorder version %d to %s, only v1 & v6", u.Version(), version)
+}
+
+// pgo-uuid convert [--from fmt] [--to fmt] [--reorder v1|v6]
+//
+// convert each stdin line, report unparseable lines with their number
+// on stderr & exit 1 after the last line
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("convert", "[--from fmt] [--to fmt] [--reorder v1|v6] < input", stderr)
+	from := fs.String("from", "auto", "input format: "+strings.Join(inputFormatNames, ", "))
+	to := fs.String("to", "canonical", "output format: "+strings.Join(formatNames, ", "))
+	version := fs.String("reorder", "", "move v1/v6 timestamp to the v1 or v6 layout")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	out := output{format: *to}
+	if err := out.check(); err != nil {
+		return fail(stderr, "convert", exitUsage, err)
+	}
+	if !slices.Contains(inputFormatNames, *from) {
+		return fail(stderr, "convert", exitUsage,
+			fmt.Errorf("unknown input format %q, accept: %s", *from, strings.Join(inputFormatNames, ", ")))
+	}
+	if *version != "" && *version != "v1" && *version != "v6" {
+		return fail(stderr, "convert", exitUsage, fmt.Errorf("--reorder %q, want v1 or v6", *version))
+	}
+	if fs.NArg() > 0 {
+		return fail(stderr, "convert", exitUsage, fmt.Errorf("unexpected argument %q, input is read from stdin", fs.Arg(0)))
+	}
+
+	bw := bufio.NewWriter(stdout)
+	defer bw.Flush()
+
+	status := exitOK
+	sc := bufio.NewScanner(stdin)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		u, err := parseUUID(line, *from)
+		if err == nil {
+			u, err = reorder(u, *version)
+		}
+		if err != nil {
+			fail(stderr, "convert", exitFailure, fmt.Errorf("line %d: %q: %w", n, line, err))
+			status = exitFailure
+			continue
+		}
+		if err := out.write(bw, u); err != nil {
+			return fail(stderr, "convert", exitFailure, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fail(stderr, "convert", exitFailure, err)
+	}
+	return status
+}
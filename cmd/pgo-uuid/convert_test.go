@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestParseUUID tests every input format against formatUUID output
+func TestParseUUID(t *testing.T) {
+	u, _ := parseUUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "auto")
+	for _, format := range inputFormatNames {
+		s, err := formatUUID(u, format)
+		if format == "auto" {
+			s, err = u.String(), nil
+		}
+		if err != nil {
+			t.Fatalf("formatUUID(%s) error = %v", format, err)
+		}
+		got, err := parseUUID(s, format)
+		if err != nil || got != u {
+			t.Errorf("parseUUID(%q, %s) = %s, %v, want %s", s, format, got, err, u)
+		}
+	}
+
+	// sql server literal
+	if got, err := parseUUID("0xE2227F01B079C37C98C4DC0C0C07398F", "guid"); err != nil || got != u {
+		t.Errorf("parseUUID(0x guid) = %s, %v", got, err)
+	}
+	if _, err := parseUUID("AX8i4nmw", "base64url"); err == nil {
+		t.Error("parseUUID() short input expected error")
+	}
+}
+
+// TestRunConvert tests formats, reordering & per line errors
+func TestRunConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantErr  string
+		wantCode int
+	}{
+		{
+			"guid to canonical",
+			[]string{"--from", "guid"},
+			"E2227F01B079C37C98C4DC0C0C07398F\n",
+			"017f22e2-79b0-7cc3-98c4-dc0c0c07398f\n", "", 0,
+		},
+		{
+			"hex to base64url",
+			[]string{"--to", "base64url"},
+			"017f22e279b07cc398c4dc0c0c07398f\n",
+			"AX8i4nmwfMOYxNwMDAc5jw\n", "", 0,
+		},
+		{
+			"v1 to v6",
+			[]string{"--reorder", "v6"},
+			"c232ab00-9414-11ec-b3c8-9f6bdeced846\n1ec9414c-232a-6b00-b3c8-9f6bdeced846\n",
+			"1ec9414c-232a-6b00-b3c8-9f6bdeced846\n1ec9414c-232a-6b00-b3c8-9f6bdeced846\n", "", 0,
+		},
+		{
+			"v6 to v1 guid",
+			[]string{"--reorder", "v1", "--to", "guid"},
+			"1ec9414c-232a-6b00-b3c8-9f6bdeced846\n",
+			"00AB32C21494EC11B3C89F6BDECED846\n", "", 0,
+		},
+		{
+			"bad lines",
+			[]string{"--reorder", "v6"},
+			"nope\n919108f7-52d1-4320-9bac-f847db4148a8\nc232ab00-9414-11ec-b3c8-9f6bdeced846\n",
+			"1ec9414c-232a-6b00-b3c8-9f6bdeced846\n",
+			"line 1: \"nope\"", 1,
+		},
+		{"unknown from", []string{"--from", "raw"}, "", "", "unknown input format", 2},
+		{"unknown to", []string{"--to", "octal"}, "", "", "unknown format", 2},
+		{"bad reorder", []string{"--reorder", "v7"}, "", "", "--reorder", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runConvert(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("runConvert() = %d, want %d, stderr = %s", code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("runConvert() = %q, want %q", stdout.String(), tt.want)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("runConvert() stderr = %q, want %q", stderr.String(), tt.wantErr)
+			}
+		})
+	}
+}
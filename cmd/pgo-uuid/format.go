@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// names accepted by --format, guid ones are in Microsoft mixed endian byte order
+var formatNames = []string{"canonical", "uppercase", "hex", "urn", "braced", "base64", "base64url", "base32", "guid", "guid-base64", "raw"}
+
+// names accepted by parseUUID, every format but raw (the last one)
+var inputFormatNames = append([]string{"auto"}, formatNames[:len(formatNames)-1]...)
+
+var base32NoPad = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// render `u` as `format`, raw is the 16 binary bytes
+func formatUUID(u pgo.UUID, format string) (string, error) {
+	switch format {
+	case "canonical":
+		return u.String(), nil
+	case "uppercase":
+		return strings.ToUpper(u.String()), nil
+	case "hex":
+		return hex.EncodeToString(u[:]), nil
+	case "urn":
+		return "urn:uuid:" + u.String(), nil
+	case "braced":
+		return "{" + u.String() + "}", nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(u[:]), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(u[:]), nil
+	case "base32":
+		return base32NoPad.EncodeToString(u[:]), nil
+	case "guid":
+		b := u.GUIDBytes()
+		return strings.ToUpper(hex.EncodeToString(b[:])), nil
+	case "guid-base64":
+		b := u.GUIDBytes()
+		return base64.StdEncoding.EncodeToString(b[:]), nil
+	case "raw":
+		return string(u[:]), nil
+	}
+	return "", fmt.Errorf("unknown format %q, accept: %s", format, strings.Join(formatNames, ", "))
+}
+
+// parse `s` written as `format`, "auto" & every text form accept
+// anything UUIDfromString does
+func parseUUID(s, format string) (pgo.UUID, error) {
+	var b []byte
+	var err error
+	switch format {
+	case "auto", "canonical", "uppercase", "hex", "urn", "braced":
+		return pgo.UUIDfromString(s)
+	case "base64", "guid-base64":
+		b, err = base64.StdEncoding.DecodeString(s)
+	case "base64url":
+		b, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	case "base32":
+		b, err = base32NoPad.DecodeString(strings.ToUpper(strings.TrimRight(s, "=")))
+	case "guid":
+		b, err = hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	default:
+		return pgo.UUID{}, fmt.Errorf("unknown input format %q", format)
+	}
+	if err != nil {
+		return pgo.UUID{}, err
+	}
+
+	if len(b) != 16 {
+		return pgo.UUID{}, fmt.Errorf("decoded %d bytes, want 16", len(b))
+	}
+	if format == "guid" || format == "guid-base64" {
+		return pgo.UUIDfromGUIDBytes(b)
+	}
+	return pgo.UUID(b), nil
+}
+
+// how each uuid is written
+type output struct {
+	format string
+	json   bool // one inspection object per line, id rendered as format
+}
+
+// validate the combination of options
+func (o output) check() error {
+	if _, err := formatUUID(pgo.UUID{}, o.format); err != nil {
+		return err
+	}
+	if o.json && o.format == "raw" {
+		return fmt.Errorf("format raw can't be used with --json")
+	}
+	return nil
+}
+
+// write `u` to `bw`, newline terminated unless raw
+func (o output) write(bw *bufio.Writer, u pgo.UUID) error {
+	s, err := formatUUID(u, o.format)
+	if err != nil {
+		return err
+	}
+
+	if o.json {
+		in := inspect(u)
+		in.ID = s
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		bw.Write(b)
+		return bw.WriteByte('\n')
+	}
+
+	bw.WriteString(s)
+	if o.format == "raw" {
+		return nil
+	}
+	return bw.WriteByte('\n')
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// TestFormatUUID tests every --format of one RFC 9562 vector
+func TestFormatUUID(t *testing.T) {
+	u, _ := pgo.UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"canonical", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"},
+		{"uppercase", "017F22E2-79B0-7CC3-98C4-DC0C0C07398F"},
+		{"hex", "017f22e279b07cc398c4dc0c0c07398f"},
+		{"urn", "urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f"},
+		{"braced", "{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}"},
+		{"base64", "AX8i4nmwfMOYxNwMDAc5jw=="},
+		{"base64url", "AX8i4nmwfMOYxNwMDAc5jw"},
+		{"base32", "AF7SFYTZWB6MHGGE3QGAYBZZR4"},
+		{"guid", "E2227F01B079C37C98C4DC0C0C07398F"},
+		{"guid-base64", "4iJ/AbB5w3yYxNwMDAc5jw=="},
+		{"raw", string(u[:])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := formatUUID(u, tt.format)
+			if err != nil {
+				t.Fatalf("formatUUID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("formatUUID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := formatUUID(u, "octal"); err == nil {
+		t.Error("formatUUID() unknown format expected error")
+	}
+	if len(tests) != len(formatNames) {
+		t.Errorf("tested %d formats, formatNames has %d", len(tests), len(formatNames))
+	}
+}
+
+// TestOutputWrite tests line termination, raw & json output
+func TestOutputWrite(t *testing.T) {
+	u, _ := pgo.UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+
+	write := func(o output) string {
+		var buf bytes.Buffer
+		bw := bufio.NewWriter(&buf)
+		if err := o.write(bw, u); err != nil {
+			t.Fatalf("write() error = %v", err)
+		}
+		bw.Flush()
+		return buf.String()
+	}
+
+	if got := write(output{format: "braced"}); got != "{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}\n" {
+		t.Errorf("braced = %q", got)
+	}
+	if got := write(output{format: "raw"}); got != string(u[:]) {
+		t.Errorf("raw = %x", got)
+	}
+
+	var in inspection
+	if err := json.Unmarshal([]byte(write(output{format: "hex", json: true})), &in); err != nil {
+		t.Fatalf("json error = %v", err)
+	}
+	if in.ID != "017f22e279b07cc398c4dc0c0c07398f" || in.UUID != u.String() || in.Version != 7 || in.TimeUTC == "" {
+		t.Errorf("json = %+v", in)
+	}
+
+	if err := (output{format: "raw", json: true}).check(); err == nil {
+		t.Error("check() raw with json expected error")
+	}
+}
+
+// TestGenerateFormat tests count & format together
+func TestGenerateFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := generate(&buf, "v4", 100, output{format: "raw"}); err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if buf.Len() != 100*16 {
+		t.Errorf("generate() raw wrote %d bytes, want 1600", buf.Len())
+	}
+
+	buf.Reset()
+	if err := generate(&buf, "v7", 3, output{format: "urn"}); err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.HasPrefix(line, "urn:uuid:") {
+			t.Errorf("generate() urn line = %q", line)
+		}
+	}
+
+	if err := generate(&buf, "v7", 3, output{format: "octal"}); err == nil {
+		t.Error("generate() unknown format expected error")
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// get generator function of `version`
+func generator(version string) (func() (pgo.UUID, error), error) {
+	switch version {
+	case "v1":
+		return pgo.UUIDv1, nil
+	case "v4":
+		return pgo.UUIDv4, nil
+	case "v7":
+		return pgo.UUIDv7, nil
+	}
+	return nil, fmt.Errorf("unknown uuid version %q", version)
+}
+
+// write `count` uuid of `version` to `w` as `out`
+//
+// output is buffered, v7 come out in generation (time) order
+func generate(w io.Writer, version string, count int, out output) error {
+	if count < 1 {
+		return fmt.Errorf("count must be at least 1, got %d", count)
+	}
+	gen, err := generator(version)
+	if err != nil {
+		return err
+	}
+	if err := out.check(); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < count; i++ {
+		u, err := gen()
+		if err != nil {
+			bw.Flush()
+			return fmt.Errorf("fail to generate %s uuid %d of %d: %w", version, i+1, count, err)
+		}
+		if err := out.write(bw, u); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// pgo-uuid v1|v4|v7 [-n count] [--format f] [--json] [--at time | --range from,to]
+func runGenerate(version string) func(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	return func(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+		synopsis := "[-n count] [--format f] [--json]"
+		if version == "v7" {
+			synopsis += " [--at time | --range from,to]"
+		}
+		fs := newFlagSet(version, synopsis, stderr)
+		count := fs.Int("n", 1, "number of uuid to generate (shorthand)")
+		fs.IntVar(count, "count", 1, "number of uuid to generate")
+		out := outputFlags(fs)
+		var at, rng string
+		if version == "v7" {
+			fs.StringVar(&at, "at", "", "time of the uuid as RFC 3339 or unix milliseconds")
+			fs.StringVar(&rng, "range", "", "print min & max uuid of from,to for range queries, without -n")
+		}
+		if code, ok := parseFlags(fs, args); !ok {
+			return code
+		}
+
+		// usage errors first, nothing is written when one fail
+		if fs.NArg() > 0 {
+			return fail(stderr, version, exitUsage, fmt.Errorf("unexpected argument %q", fs.Arg(0)))
+		}
+		if *count < 1 {
+			return fail(stderr, version, exitUsage, fmt.Errorf("count must be at least 1, got %d", *count))
+		}
+		if err := out.check(); err != nil {
+			return fail(stderr, version, exitUsage, err)
+		}
+		var err error
+		switch {
+		case at != "" && rng != "":
+			return fail(stderr, version, exitUsage, fmt.Errorf("--at & --range can't be used together"))
+		case at != "":
+			var t time.Time
+			if t, err = parseTime(at); err != nil {
+				return fail(stderr, version, exitUsage, err)
+			}
+			// syntax is not enough, the time must fit the 48-bit v7 millis
+			if _, err = pgo.UUIDv7fromTime(t); err != nil {
+				return fail(stderr, version, exitUsage, err)
+			}
+			err = generateAt(stdout, t, *count, *out)
+		case rng != "":
+			if isFlagSet(fs, "n") || isFlagSet(fs, "count") {
+				return fail(stderr, version, exitUsage, fmt.Errorf("-n can't be used with --range, it always print 2 uuid"))
+			}
+			if _, _, err = parseRange(rng); err != nil {
+				return fail(stderr, version, exitUsage, err)
+			}
+			err = writeRange(stdout, rng, *out)
+		default:
+			err = generate(stdout, version, *count, *out)
+		}
+		if err != nil {
+			return fail(stderr, version, exitFailure, err)
+		}
+		return exitOK
+	}
+}
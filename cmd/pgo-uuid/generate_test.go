@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// writer always failing
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestGenerate tests count, uniqueness & version of generated lines
+func TestGenerate(t *testing.T) {
+	for _, version := range []string{"v1", "v4", "v7"} {
+		t.Run(version, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := generate(&buf, version, 10_000, output{format: "canonical"}); err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if len(lines) != 10_000 {
+				t.Fatalf("generate() wrote %d lines, want 10000", len(lines))
+			}
+			seen := make(map[string]bool, len(lines))
+			for _, line := range lines {
+				u, err := pgo.UUIDfromString(line)
+				if err != nil {
+					t.Fatalf("generate() wrote %q: %v", line, err)
+				}
+				if fmt.Sprintf("v%d", u.Version()) != version {
+					t.Fatalf("generate() wrote %s of version %d", line, u.Version())
+				}
+				if seen[line] {
+					t.Fatalf("generate() wrote duplicate %s", line)
+				}
+				seen[line] = true
+			}
+
+			if version == "v7" && !slices.IsSorted(lines) {
+				t.Error("generate() v7 not in order")
+			}
+		})
+	}
+}
+
+// TestGenerateErrors tests invalid arguments & write failure are reported
+func TestGenerateErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := generate(&buf, "v7", 0, output{format: "canonical"}); err == nil {
+		t.Error("generate() count 0 expected error")
+	}
+	if err := generate(&buf, "v9", 1, output{format: "canonical"}); err == nil {
+		t.Error("generate() unknown version expected error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("generate() wrote %q on error", buf.String())
+	}
+	if err := generate(errWriter{}, "v4", 10_000, output{format: "canonical"}); err == nil {
+		t.Error("generate() write failure expected error")
+	}
+}
+
+func BenchmarkGenerateV7(b *testing.B) {
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := generate(&buf, "v7", 1000, output{format: "canonical"}); err != nil {
+			b.Fatalf("generate() error = %v", err)
+		}
+	}
+}
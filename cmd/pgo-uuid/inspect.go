@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// decoded fields of one uuid, empty fields are not part of its version
+type inspection struct {
+	ID        string  `json:"id,omitempty"` // rendered by --format
+	UUID      string  `json:"uuid"`
+	Version   int     `json:"version"`
+	Variant   string  `json:"variant"`
+	TimeUTC   string  `json:"time_utc,omitempty"`
+	TimeLocal string  `json:"time_local,omitempty"`
+	ClockSeq  *uint16 `json:"clock_seq,omitempty"`
+	Node      string  `json:"node,omitempty"`
+	Multicast bool    `json:"node_multicast,omitempty"` // random node, not a MAC
+	Domain    string  `json:"domain,omitempty"`
+	LocalID   *uint32 `json:"local_id,omitempty"`
+	Counter   *uint16 `json:"counter,omitempty"`
+}
+
+// decode every field of `u`
+func inspect(u pgo.UUID) inspection {
+	in := inspection{
+		UUID:    u.String(),
+		Version: u.Version(),
+		Variant: u.Variant().String(),
+	}
+	if t, ok := u.Time(); ok {
+		in.TimeUTC = t.UTC().Format(time.RFC3339Nano)
+		in.TimeLocal = t.Local().Format(time.RFC3339Nano)
+	}
+	if seq, ok := u.ClockSequence(); ok {
+		in.ClockSeq = &seq
+	}
+	if node, ok := u.NodeID(); ok {
+		in.Node = node.String()
+		in.Multicast = node[0]&0x01 != 0
+	}
+	if u.Version() == 2 {
+		id := u.ID()
+		in.Domain = u.Domain().String()
+		in.LocalID = &id
+	}
+	if c, ok := u.Counter(); ok {
+		in.Counter = &c
+	}
+	return in
+}
+
+// write `in` as aligned "key: value" lines
+func writeInspection(w io.Writer, in inspection) {
+	fmt.Fprintf(w, "uuid:       %s\n", in.UUID)
+	fmt.Fprintf(w, "version:    %d\n", in.Version)
+	fmt.Fprintf(w, "variant:    %s\n", in.Variant)
+	if in.TimeUTC != "" {
+		fmt.Fprintf(w, "time utc:   %s\n", in.TimeUTC)
+		fmt.Fprintf(w, "time local: %s\n", in.TimeLocal)
+	}
+	if in.ClockSeq != nil {
+		fmt.Fprintf(w, "clock seq:  %d\n", *in.ClockSeq)
+	}
+	if in.Node != "" {
+		kind := "mac"
+		if in.Multicast {
+			kind = "random"
+		}
+		fmt.Fprintf(w, "node:       %s (%s)\n", in.Node, kind)
+	}
+	if in.LocalID != nil {
+		fmt.Fprintf(w, "domain:     %s\n", in.Domain)
+		fmt.Fprintf(w, "local id:   %d\n", *in.LocalID)
+	}
+	if in.Counter != nil {
+		fmt.Fprintf(w, "counter:    %d\n", *in.Counter)
+	}
+}
+
+// pgo-uuid inspect [--json] [uuid...]
+//
+// decode uuid from the arguments, or from stdin one per line when none,
+// return exit status, 1 if one of them is invalid
+func runInspect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("inspect", "[--json] [uuid...]", stderr)
+	asJSON := fs.Bool("json", false, "print one json object per line")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	bw := bufio.NewWriter(stdout)
+	defer bw.Flush()
+	enc := json.NewEncoder(bw)
+
+	// decode & write one input, flushed so a pipe see it at once
+	status, written := exitOK, 0
+	each := func(s string) {
+		defer bw.Flush()
+		u, err := pgo.UUIDfromString(s)
+		if err != nil {
+			status = fail(stderr, "inspect", exitFailure, fmt.Errorf("%q: %w", s, err))
+			return
+		}
+
+		if *asJSON {
+			enc.Encode(inspect(u))
+			return
+		}
+		if written > 0 {
+			bw.WriteByte('\n')
+		}
+		writeInspection(bw, inspect(u))
+		written++
+	}
+
+	if inputs := fs.Args(); len(inputs) > 0 {
+		for _, s := range inputs {
+			each(s)
+		}
+		return status
+	}
+
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			each(line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fail(stderr, "inspect", exitFailure, err)
+	}
+	return status
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunInspect tests human readable output of RFC 9562 vectors
+func TestRunInspect(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := runInspect([]string{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846",
+		"urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+	}, strings.NewReader(""), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("runInspect() = %d, stderr = %s", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"uuid:       c232ab00-9414-11ec-b3c8-9f6bdeced846\nversion:    1\nvariant:    RFC 9562\n",
+		"time utc:   2022-02-22T19:22:22Z\n",
+		"clock seq:  13256\n",
+		"node:       9f:6b:de:ce:d8:46 (random)\n",
+		"\n\nuuid:       017f22e2-79b0-7cc3-98c4-dc0c0c07398f\nversion:    7\n",
+		"counter:    3267\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("runInspect() output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// TestRunInspectJSON tests json lines read from stdin & invalid input
+func TestRunInspectJSON(t *testing.T) {
+	stdin := strings.NewReader("919108f7-52d1-4320-9bac-f847db4148a8\n\nnot-a-uuid\n000003e8-9414-21ec-8100-9f6bdeced846\n")
+	var stdout, stderr bytes.Buffer
+	code := runInspect([]string{"--json"}, stdin, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("runInspect() = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), `"not-a-uuid"`) {
+		t.Errorf("stderr = %q, want invalid input reported", stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("runInspect() wrote %d lines, want 2:\n%s", len(lines), stdout.String())
+	}
+
+	var v4, v2 map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &v4); err != nil {
+		t.Fatalf("json error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &v2); err != nil {
+		t.Fatalf("json error = %v", err)
+	}
+	if v4["version"] != 4.0 || v4["time_utc"] != nil || v4["node"] != nil {
+		t.Errorf("v4 = %v", v4)
+	}
+	if v2["version"] != 2.0 || v2["domain"] != "person" || v2["local_id"] != 1000.0 {
+		t.Errorf("v2 = %v", v2)
+	}
+}
+
+// TestRunInspectStream tests each stdin line is written before the next is read
+func TestRunInspectStream(t *testing.T) {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan int, 1)
+	go func() {
+		done <- runInspect([]string{"--json"}, inR, outW, io.Discard)
+		outW.Close()
+	}()
+
+	// stdin still open, the first object must already come out
+	go io.WriteString(inW, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f\n")
+	line, err := bufio.NewReader(outR).ReadString('\n')
+	if err != nil || !strings.Contains(line, `"version":7`) {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+
+	inW.Close()
+	go io.Copy(io.Discard, outR)
+	if code := <-done; code != exitOK {
+		t.Errorf("runInspect() = %d, want %d", code, exitOK)
+	}
+}
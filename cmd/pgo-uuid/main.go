@@ -1,38 +1,134 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-
-	"github.com/prothegee/pgo/uuid"
+	"strings"
 )
 
+// exit status of every command
 const (
-	DEFAULT_OUTPUT = "nothing to generate; only accept `v1` `v4` & `v7` as the arg"
+	exitOK      = 0
+	exitFailure = 1 // generation, io or validation failure
+	exitUsage   = 2 // bad command, flag or argument
 )
 
+// set at build time: go build -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+// one subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"v1", "generate time based uuid v1", runGenerate("v1")},
+	{"v3", "generate name based uuid v3 (md5)", runNameBased("v3")},
+	{"v4", "generate random uuid v4", runGenerate("v4")},
+	{"v5", "generate name based uuid v5 (sha-1)", runNameBased("v5")},
+	{"v7", "generate time ordered uuid v7", runGenerate("v7")},
+	{"inspect", "decode version, time, node & counter of uuid", runInspect},
+	{"validate", "check files of uuid, one per line", runValidate},
+	{"convert", "convert uuid between representations", runConvert},
+	{"stats", "summarize a file of uuid", runStats},
+	{"serve", "run the http id service", runServe},
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println(DEFAULT_OUTPUT)
-		return
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run pgo-uuid with `args` (without the program name)
+//
+// return: int - exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
 	}
 
-	arg := os.Args[1]
-	if arg != "v1" && arg != "v4" && arg != "v7" {
-		fmt.Println(DEFAULT_OUTPUT)
-		return
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		usage(stdout)
+		return exitOK
+	case "-version", "--version", "version":
+		fmt.Fprintf(stdout, "pgo-uuid %s\n", version)
+		return exitOK
 	}
 
-	if arg == "v1" {
-		res, _ := pgo.UUIDv1asString()
-		fmt.Println(res)
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdin, stdout, stderr)
+		}
 	}
-	if arg == "v4" {
-		res, _ := pgo.UUIDv4asString()
-		fmt.Println(res)
+
+	fmt.Fprintf(stderr, "pgo-uuid: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: pgo-uuid <command> [flags] [args]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.summary)
 	}
-	if arg == "v7" {
-		res, _ := pgo.UUIDv7asString()
-		fmt.Println(res)
+	fmt.Fprintf(w, "\nrun `pgo-uuid <command> -h` for the flags of a command\n")
+}
+
+// --------------------------------------------------------- //
+
+// get flag set of subcommand `name`, usage & errors go to `stderr`
+//
+// `synopsis` is the argument part of the usage line
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("pgo-uuid "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: pgo-uuid %s %s\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse `args`, when not ok the command must return `code`
+// (exitOK for -h, exitUsage otherwise)
+func parseFlags(fs *flag.FlagSet, args []string) (code int, ok bool) {
+	err := fs.Parse(args)
+	switch {
+	case err == nil:
+		return exitOK, true
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK, false
 	}
+	return exitUsage, false
+}
+
+// report whether flag `name` was given on the command line
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// register --format & --json on `fs`
+func outputFlags(fs *flag.FlagSet) *output {
+	out := &output{}
+	fs.StringVar(&out.format, "format", "canonical", "output format: "+strings.Join(formatNames, ", "))
+	fs.BoolVar(&out.json, "json", false, "print one json object with metadata per uuid")
+	return out
+}
+
+// print `err` of command `name` & return `code`
+func fail(stderr io.Writer, name string, code int, err error) int {
+	fmt.Fprintf(stderr, "pgo-uuid %s: %v\n", name, err)
+	return code
 }
@@ -1,9 +1,114 @@
 package main
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 )
 
-func TestPgoUuid(t *testing.T) {
-	t.Skip("pgo-uuid; not much to test")
+// helper to run pgo-uuid in process
+func runCmd(t *testing.T, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+// TestRunTopLevel tests help, version & unknown command
+func TestRunTopLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{"no args", nil, exitUsage, "", "usage: pgo-uuid"},
+		{"help", []string{"-h"}, exitOK, "usage: pgo-uuid", ""},
+		{"help long", []string{"--help"}, exitOK, "inspect", ""},
+		{"version", []string{"--version"}, exitOK, "pgo-uuid dev\n", ""},
+		{"unknown", []string{"v9"}, exitUsage, "", `unknown command "v9"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCmd(t, "", tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("run(%q) = %d, want %d", tt.args, code, tt.wantCode)
+			}
+			if !strings.Contains(stdout, tt.wantStdout) || (tt.wantStdout == "" && stdout != "") {
+				t.Errorf("run(%q) stdout = %q, want %q", tt.args, stdout, tt.wantStdout)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) || (tt.wantStderr == "" && stderr != "") {
+				t.Errorf("run(%q) stderr = %q, want %q", tt.args, stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+// TestRunSubcommandHelp tests -h & bad flags of every subcommand
+func TestRunSubcommandHelp(t *testing.T) {
+	for _, c := range commands {
+		t.Run(c.name, func(t *testing.T) {
+			code, stdout, stderr := runCmd(t, "", c.name, "-h")
+			if code != exitOK || stdout != "" || !strings.HasPrefix(stderr, "usage: pgo-uuid "+c.name) {
+				t.Errorf("%s -h = %d, stdout %q, stderr %q", c.name, code, stdout, stderr)
+			}
+
+			code, stdout, _ = runCmd(t, "", c.name, "--no-such-flag")
+			if code != exitUsage || stdout != "" {
+				t.Errorf("%s --no-such-flag = %d, stdout %q", c.name, code, stdout)
+			}
+		})
+	}
+}
+
+// TestRunGenerate tests generation through run & its usage errors
+func TestRunGenerate(t *testing.T) {
+	code, stdout, stderr := runCmd(t, "", "v7", "-n", "3", "--format", "uppercase")
+	if lines := strings.Fields(stdout); code != exitOK || len(lines) != 3 || lines[0] != strings.ToUpper(lines[0]) {
+		t.Errorf("v7 -n 3 = %d, %q, %q", code, stdout, stderr)
+	}
+
+	for _, args := range [][]string{
+		{"v4", "-n", "0"},
+		{"v4", "extra"},
+		{"v4", "--at", "1"},
+		{"v7", "--at", "yesterday"},
+		{"v7", "--at", "1", "--range", "1,2"},
+		{"v7", "--range", "2,1"},
+		{"v7", "--range", "1,2", "-n", "5"},
+		{"v7", "--range", "1,2", "--count", "1"},
+		{"v7", "--at=-5"},
+		{"v7", "--at", "99999999999999999"},
+		{"v1", "--format", "octal"},
+	} {
+		code, stdout, stderr := runCmd(t, "", args...)
+		if code != exitUsage || stdout != "" || stderr == "" {
+			t.Errorf("run(%q) = %d, stdout %q, stderr %q, want usage error", args, code, stdout, stderr)
+		}
+	}
+}
+
+// TestRunExitCodes tests failure vs usage status of the line based commands
+func TestRunExitCodes(t *testing.T) {
+	tests := []struct {
+		args  []string
+		stdin string
+		want  int
+	}{
+		{[]string{"inspect", "nope"}, "", exitFailure},
+		{[]string{"validate"}, "nope\n", exitFailure},
+		{[]string{"validate", "--version", "0"}, "", exitUsage},
+		{[]string{"convert"}, "nope\n", exitFailure},
+		{[]string{"convert", "file.txt"}, "", exitUsage},
+		{[]string{"v5"}, "", exitUsage},
+		{[]string{"v5", "dns", "a"}, "", exitOK},
+		{[]string{"serve", "extra"}, "", exitUsage},
+	}
+	for _, tt := range tests {
+		if code, _, stderr := runCmd(t, tt.stdin, tt.args...); code != tt.want {
+			t.Errorf("run(%q) = %d, want %d, stderr %q", tt.args, code, tt.want, stderr)
+		}
+	}
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// well-known namespaces by name
+var namespaces = map[string]pgo.UUID{
+	"dns":  pgo.NamespaceDNS,
+	"url":  pgo.NamespaceURL,
+	"oid":  pgo.NamespaceOID,
+	"x500": pgo.NamespaceX500,
+}
+
+// get namespace from a well-known name (dns, url, oid, x500) or any uuid form
+func parseNamespace(s string) (pgo.UUID, error) {
+	if ns, ok := namespaces[strings.ToLower(s)]; ok {
+		return ns, nil
+	}
+	ns, err := pgo.UUIDfromString(s)
+	if err != nil {
+		return ns, fmt.Errorf("namespace %q is neither dns, url, oid, x500 nor a uuid: %w", s, err)
+	}
+	return ns, nil
+}
+
+// pgo-uuid v3|v5 [--format f] [--json] <namespace> [name...]
+//
+// map each name to its uuid, names are read from stdin one per line when
+// none given, every line (empty one included) give exactly one output line
+func runNameBased(version string) func(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	return func(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+		fs := newFlagSet(version, "[--format f] [--json] <dns|url|oid|x500|uuid> [name...]", stderr)
+		out := outputFlags(fs)
+		if code, ok := parseFlags(fs, args); !ok {
+			return code
+		}
+		if err := out.check(); err != nil {
+			return fail(stderr, version, exitUsage, err)
+		}
+
+		if fs.NArg() == 0 {
+			return fail(stderr, version, exitUsage, fmt.Errorf("missing namespace (dns, url, oid, x500 or uuid)"))
+		}
+		ns, err := parseNamespace(fs.Arg(0))
+		if err != nil {
+			return fail(stderr, version, exitUsage, err)
+		}
+
+		hash := pgo.UUIDv5
+		if version == "v3" {
+			hash = pgo.UUIDv3
+		}
+
+		bw := bufio.NewWriter(stdout)
+		write := func(name string) error {
+			return out.write(bw, hash(ns, name))
+		}
+
+		if names := fs.Args()[1:]; len(names) > 0 {
+			for _, name := range names {
+				if err := write(name); err != nil {
+					return fail(stderr, version, exitFailure, err)
+				}
+			}
+		} else {
+			sc := bufio.NewScanner(stdin)
+			for sc.Scan() {
+				if err := write(strings.TrimSuffix(sc.Text(), "\r")); err != nil {
+					return fail(stderr, version, exitFailure, err)
+				}
+			}
+			if err := sc.Err(); err != nil {
+				bw.Flush()
+				return fail(stderr, version, exitFailure, err)
+			}
+		}
+
+		if err := bw.Flush(); err != nil {
+			return fail(stderr, version, exitFailure, err)
+		}
+		return exitOK
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestParseNamespace tests well-known names & explicit uuid
+func TestParseNamespace(t *testing.T) {
+	for _, s := range []string{"dns", "DNS", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"} {
+		ns, err := parseNamespace(s)
+		if err != nil || ns.String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+			t.Errorf("parseNamespace(%q) = %s, %v", s, ns, err)
+		}
+	}
+	if _, err := parseNamespace("ldap"); err == nil {
+		t.Error("parseNamespace(ldap) expected error")
+	}
+}
+
+// TestRunNameBased tests RFC 9562 vectors from arguments & stdin
+func TestRunNameBased(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		args    []string
+		stdin   string
+		want    string
+	}{
+		{"v3 args", "v3", []string{"dns", "www.example.com"}, "", "5df41881-3aed-3515-88a7-2f4a814cf09e\n"},
+		{"v5 args", "v5", []string{"dns", "www.example.com", "www.example.com"}, "",
+			"2ed6657d-e927-568b-95e1-2665a8aea6a2\n2ed6657d-e927-568b-95e1-2665a8aea6a2\n"},
+		{"v5 stdin crlf", "v5", []string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}, "www.example.com\r\n", "2ed6657d-e927-568b-95e1-2665a8aea6a2\n"},
+		{"v5 format", "v5", []string{"--format", "uppercase", "dns", "www.example.com"}, "", "2ED6657D-E927-568B-95E1-2665A8AEA6A2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runNameBased(tt.version)(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); code != 0 {
+				t.Fatalf("runNameBased() = %d, stderr = %s", code, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("runNameBased() = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestRunNameBasedLines tests one output line per input line, empty included
+func TestRunNameBasedLines(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := runNameBased("v5")([]string{"url"}, strings.NewReader("a\n\nb\n"), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("runNameBased() = %d, stderr = %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[0] == lines[1] || lines[0] == lines[2] {
+		t.Errorf("runNameBased() lines = %q", lines)
+	}
+}
+
+// TestRunNameBasedErrors tests usage errors exit with 2
+func TestRunNameBasedErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"ldap", "a"},
+		{"--format", "octal", "dns", "a"},
+		{"--json", "--format", "raw", "dns", "a"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := runNameBased("v5")(args, strings.NewReader(""), &stdout, &stderr); code != 2 {
+			t.Errorf("runNameBased(%q) = %d, want 2", args, code)
+		}
+		if stderr.Len() == 0 || stdout.Len() != 0 {
+			t.Errorf("runNameBased(%q) stdout = %q, stderr = %q", args, stdout.String(), stderr.String())
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+const (
+	serveMaxCount        = 10_000 // largest n of one request
+	serveShutdownTimeout = 10 * time.Second
+)
+
+// id service, every request share the same generators
+type idService struct {
+	v1 *pgo.UUIDv1Generator
+	v7 *pgo.UUIDGeneratorV7
+}
+
+// get handler of the id service
+//
+//	GET /v1?n=10    GET /v4?n=10    GET /v7?n=10
+//	GET /inspect/{id}
+//
+// text/plain one id per line, or json with ?format=json or an
+// Accept: application/json header
+func newIDService() (http.Handler, error) {
+	v1, err := pgo.NewUUIDv1Generator()
+	if err != nil {
+		return nil, err
+	}
+	v7, err := pgo.NewUUIDGeneratorV7()
+	if err != nil {
+		return nil, err
+	}
+	s := &idService{v1: v1, v7: v7}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1", s.generate(s.v1.NewV1))
+	mux.HandleFunc("GET /v4", s.generate(pgo.UUIDv4asString))
+	mux.HandleFunc("GET /v7", s.generate(s.v7.NewV7))
+	mux.HandleFunc("GET /inspect/{id}", s.inspect)
+	return mux, nil
+}
+
+// report whether the client asked for json
+func wantJSON(r *http.Request) bool {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f == "json"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// handler writing n ids of `gen`
+func (s *idService) generate(gen func() (string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := 1
+		if q := r.URL.Query().Get("n"); q != "" {
+			var err error
+			if n, err = strconv.Atoi(q); err != nil || n < 1 || n > serveMaxCount {
+				http.Error(w, fmt.Sprintf("n must be between 1 and %d", serveMaxCount), http.StatusBadRequest)
+				return
+			}
+		}
+
+		ids := make([]string, n)
+		for i := range ids {
+			id, err := gen()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			ids[i] = id
+		}
+
+		if wantJSON(r) {
+			writeJSON(w, map[string][]string{"ids": ids})
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, strings.Join(ids, "\n")+"\n")
+	}
+}
+
+func (s *idService) inspect(w http.ResponseWriter, r *http.Request) {
+	u, err := pgo.UUIDfromString(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if wantJSON(r) {
+		writeJSON(w, inspect(u))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writeInspection(w, inspect(u))
+}
+
+// listen on "host:port" or "unix:/path"
+//
+// a socket file left by a killed process is removed first,
+// a socket still accepting connections is not
+func listen(addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			if conn, err := net.Dial("unix", path); err == nil {
+				conn.Close()
+			} else if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// serve `h` on `ln` until `ctx` is done, then wait for in flight requests
+func serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	srv := &http.Server{Handler: h, ReadHeaderTimeout: 10 * time.Second}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// pgo-uuid serve [--listen addr|unix:/path]
+//
+// run the id service till SIGINT or SIGTERM
+func runServe(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("serve", "[--listen addr|unix:/path]", stderr)
+	addr := fs.String("listen", "127.0.0.1:8080", "address as host:port or unix:/path/to/socket")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return fail(stderr, "serve", exitUsage, fmt.Errorf("unexpected argument %q", fs.Arg(0)))
+	}
+
+	h, err := newIDService()
+	if err != nil {
+		return fail(stderr, "serve", exitFailure, err)
+	}
+	ln, err := listen(*addr)
+	if err != nil {
+		return fail(stderr, "serve", exitFailure, err)
+	}
+	fmt.Fprintf(stdout, "listening on %s\n", ln.Addr())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := serve(ctx, ln, h); err != nil {
+		return fail(stderr, "serve", exitFailure, err)
+	}
+	return exitOK
+}
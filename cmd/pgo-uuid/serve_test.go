@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// helper to start the id service
+func newTestService(t *testing.T) *httptest.Server {
+	t.Helper()
+	h, err := newIDService()
+	if err != nil {
+		t.Fatalf("newIDService() error = %v", err)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// helper GET `url` with optional Accept header, return status & body
+func get(t *testing.T, url, accept string) (int, string) {
+	t.Helper()
+	code, body, err := fetch(url, accept)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	return code, body
+}
+
+// same as get but return the error, safe outside the test goroutine
+func fetch(url, accept string) (int, string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	return res.StatusCode, string(body), err
+}
+
+// TestServeGenerate tests text & json generation endpoints
+func TestServeGenerate(t *testing.T) {
+	srv := newTestService(t)
+
+	for _, version := range []int{1, 4, 7} {
+		path := fmt.Sprintf("%s/v%d", srv.URL, version)
+
+		code, body := get(t, path+"?n=5", "")
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		if code != http.StatusOK || len(lines) != 5 {
+			t.Fatalf("GET %s?n=5 = %d, %q", path, code, body)
+		}
+		for _, line := range lines {
+			if u, err := pgo.UUIDfromString(line); err != nil || u.Version() != version {
+				t.Errorf("GET %s = %q, %v", path, line, err)
+			}
+		}
+
+		code, body = get(t, path, "application/json")
+		var res struct{ IDs []string }
+		if err := json.Unmarshal([]byte(body), &res); err != nil || code != http.StatusOK || len(res.IDs) != 1 {
+			t.Errorf("GET %s json = %d, %q, %v", path, code, body, err)
+		}
+	}
+
+	for _, q := range []string{"?n=0", "?n=x", "?n=10001"} {
+		if code, _ := get(t, srv.URL+"/v7"+q, ""); code != http.StatusBadRequest {
+			t.Errorf("GET /v7%s = %d, want 400", q, code)
+		}
+	}
+	if code, _ := get(t, srv.URL+"/v9", ""); code != http.StatusNotFound {
+		t.Errorf("GET /v9 = %d, want 404", code)
+	}
+}
+
+// TestServeMonotonic tests concurrent clients share one v7 generator
+func TestServeMonotonic(t *testing.T) {
+	srv := newTestService(t)
+
+	var mu sync.Mutex
+	var all []string
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, body, err := fetch(srv.URL+"/v7?n=500", "")
+			if err != nil {
+				t.Errorf("GET /v7 error = %v", err)
+				return
+			}
+			lines := strings.Fields(body)
+			if !slices.IsSorted(lines) {
+				t.Error("GET /v7 batch not sorted")
+			}
+			mu.Lock()
+			all = append(all, lines...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(all)
+	if len(slices.Compact(all)) != 8*500 {
+		t.Error("GET /v7 returned duplicates across clients")
+	}
+}
+
+// TestServeInspect tests text & json inspection
+func TestServeInspect(t *testing.T) {
+	srv := newTestService(t)
+
+	code, body := get(t, srv.URL+"/inspect/017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "")
+	if code != http.StatusOK || !strings.Contains(body, "time utc:   2022-02-22T19:22:22Z") {
+		t.Errorf("GET /inspect = %d, %q", code, body)
+	}
+
+	code, body = get(t, srv.URL+"/inspect/c232ab00-9414-11ec-b3c8-9f6bdeced846?format=json", "")
+	var in inspection
+	if err := json.Unmarshal([]byte(body), &in); err != nil || code != http.StatusOK || in.Node != "9f:6b:de:ce:d8:46" {
+		t.Errorf("GET /inspect json = %d, %q, %v", code, body, err)
+	}
+
+	if code, _ := get(t, srv.URL+"/inspect/nope", ""); code != http.StatusBadRequest {
+		t.Errorf("GET /inspect/nope = %d, want 400", code)
+	}
+}
+
+// TestServeShutdown tests graceful stop on a unix socket
+func TestServeShutdown(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "pgo.sock")
+	ln, err := listen("unix:" + sock)
+	if err != nil {
+		t.Skipf("unix socket unavailable: %v", err)
+	}
+	h, _ := newIDService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, ln, h) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	res, err := client.Get("http://pgo/v4")
+	if err != nil {
+		t.Fatalf("GET over unix socket error = %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("GET over unix socket = %d", res.StatusCode)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve() did not stop")
+	}
+}
+
+// TestListenStaleSocket tests a socket file of a dead process is reused
+func TestListenStaleSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "pgo.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix socket unavailable: %v", err)
+	}
+	// keep the file on close, as a killed process would
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+	if _, err := os.Lstat(sock); err != nil {
+		t.Fatalf("stale socket missing: %v", err)
+	}
+
+	ln, err = listen("unix:" + sock)
+	if err != nil {
+		t.Fatalf("listen() on stale socket error = %v", err)
+	}
+	defer ln.Close()
+
+	// a live socket must not be taken over
+	if other, err := listen("unix:" + sock); err == nil {
+		other.Close()
+		t.Error("listen() on live socket expected error")
+	}
+
+	// not a socket, left alone
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0o644)
+	if _, err := listen("unix:" + file); err == nil {
+		t.Error("listen() on regular file expected error")
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("listen() removed regular file: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// count of uuid v1 made on one node
+type nodeCount struct {
+	Node  string `json:"node"`
+	Count int    `json:"count"`
+}
+
+// summary of a stream of uuid, updated line by line
+//
+// memory grow with the distinct ids (duplicate detection) & v1 nodes,
+// not with the input size
+type stats struct {
+	Lines             int            `json:"lines"`
+	Invalid           int            `json:"invalid"`
+	Versions          map[string]int `json:"versions"`
+	Duplicates        int            `json:"duplicates"`
+	V7OrderViolations int            `json:"v7_order_violations"`
+	TimeFirst         string         `json:"time_first,omitempty"` // of v1, v6 & v7
+	TimeLast          string         `json:"time_last,omitempty"`
+	Nodes             []nodeCount    `json:"v1_nodes,omitempty"` // most used first
+
+	seen        *pgo.Set
+	lastV7      pgo.UUID
+	first, last time.Time
+	nodes       map[string]int
+}
+
+func newStats() *stats {
+	return &stats{
+		Versions: make(map[string]int),
+		seen:     pgo.NewSet(),
+		nodes:    make(map[string]int),
+	}
+}
+
+// account one input line
+func (s *stats) add(line string) {
+	s.Lines++
+	u, err := pgo.UUIDfromString(strings.TrimSpace(line))
+	if err != nil {
+		s.Invalid++
+		return
+	}
+
+	s.Versions[fmt.Sprintf("v%d", u.Version())]++
+	if !s.seen.Add(u) {
+		s.Duplicates++
+	}
+
+	if t, ok := u.Time(); ok {
+		if s.first.IsZero() || t.Before(s.first) {
+			s.first = t
+		}
+		if t.After(s.last) {
+			s.last = t
+		}
+	}
+
+	switch u.Version() {
+	case 1:
+		node, _ := u.NodeID()
+		s.nodes[node.String()]++
+	case 7:
+		if u.Compare(s.lastV7) < 0 {
+			s.V7OrderViolations++
+		}
+		s.lastV7 = u
+	}
+}
+
+// fill the exported summary, keep the `top` most used nodes (all if 0)
+func (s *stats) finish(top int) {
+	if !s.first.IsZero() {
+		s.TimeFirst = s.first.UTC().Format(time.RFC3339Nano)
+		s.TimeLast = s.last.UTC().Format(time.RFC3339Nano)
+	}
+
+	s.Nodes = s.Nodes[:0]
+	for node, n := range s.nodes {
+		s.Nodes = append(s.Nodes, nodeCount{node, n})
+	}
+	slices.SortFunc(s.Nodes, func(a, b nodeCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Node, b.Node)
+	})
+	if top > 0 && len(s.Nodes) > top {
+		s.Nodes = s.Nodes[:top]
+	}
+}
+
+// write the summary as aligned "key: value" lines
+func (s *stats) writeText(w io.Writer) {
+	fmt.Fprintf(w, "lines:         %d\n", s.Lines)
+	fmt.Fprintf(w, "invalid:       %d\n", s.Invalid)
+	fmt.Fprintf(w, "duplicates:    %d\n", s.Duplicates)
+
+	versions := make([]string, 0, len(s.Versions))
+	for v := range s.Versions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	for _, v := range versions {
+		n := s.Versions[v]
+		fmt.Fprintf(w, "version %s:    %d (%.1f%%)\n", v, n, 100*float64(n)/float64(s.Lines))
+	}
+
+	if s.TimeFirst != "" {
+		fmt.Fprintf(w, "time first:    %s\n", s.TimeFirst)
+		fmt.Fprintf(w, "time last:     %s\n", s.TimeLast)
+		fmt.Fprintf(w, "time span:     %s\n", s.last.Sub(s.first))
+	}
+	if s.Versions["v7"] > 0 {
+		fmt.Fprintf(w, "v7 unordered:  %d\n", s.V7OrderViolations)
+	}
+	if len(s.Nodes) > 0 {
+		fmt.Fprintf(w, "v1 nodes:      %d\n", len(s.nodes))
+		for _, n := range s.Nodes {
+			fmt.Fprintf(w, "  %s  %d\n", n.Node, n.Count)
+		}
+	}
+}
+
+// pgo-uuid stats [--json] [--top N] [file...]
+//
+// summarize one uuid per line from the files, or stdin when none (or "-")
+func runStats(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("stats", "[--json] [--top N] [file...]", stderr)
+	asJSON := fs.Bool("json", false, "print the summary as json")
+	top := fs.Int("top", 10, "number of v1 nodes listed, 0 for all")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *top < 0 {
+		return fail(stderr, "stats", exitUsage, fmt.Errorf("top must not be negative, got %d", *top))
+	}
+
+	s := newStats()
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		var err error
+		if name == "-" {
+			err = s.read(stdin)
+		} else {
+			err = s.readFile(name)
+		}
+		if err != nil {
+			return fail(stderr, "stats", exitFailure, err)
+		}
+	}
+	s.finish(*top)
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(s); err != nil {
+			return fail(stderr, "stats", exitFailure, err)
+		}
+		return exitOK
+	}
+	s.writeText(stdout)
+	return exitOK
+}
+
+func (s *stats) read(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		s.add(sc.Text())
+	}
+	return sc.Err()
+}
+
+func (s *stats) readFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.read(f)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const statsSample = `017f22e2-79b0-7cc3-98c4-dc0c0c07398f
+017f22e2-79b0-7cc4-98c4-dc0c0c07398f
+017f22e2-79af-7000-8000-000000000000
+c232ab00-9414-11ec-b3c8-9f6bdeced846
+c232ab01-9414-11ec-b3c8-9f6bdeced846
+c232ab02-9414-11ec-b3c8-010203040506
+919108f7-52d1-4320-9bac-f847db4148a8
+919108f7-52d1-4320-9bac-f847db4148a8
+not-a-uuid
+`
+
+// TestRunStatsJSON tests every counter of the summary
+func TestRunStatsJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runStats([]string{"--json"}, strings.NewReader(statsSample), &stdout, &stderr); code != exitOK {
+		t.Fatalf("runStats() = %d, stderr = %s", code, stderr.String())
+	}
+
+	var got stats
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("json error = %v", err)
+	}
+	if got.Lines != 9 || got.Invalid != 1 || got.Duplicates != 1 || got.V7OrderViolations != 1 {
+		t.Errorf("lines %d, invalid %d, duplicates %d, v7 unordered %d, want 9, 1, 1, 1",
+			got.Lines, got.Invalid, got.Duplicates, got.V7OrderViolations)
+	}
+	if got.Versions["v1"] != 3 || got.Versions["v4"] != 2 || got.Versions["v7"] != 3 {
+		t.Errorf("versions = %v", got.Versions)
+	}
+	if got.TimeFirst != "2022-02-22T19:22:21.999Z" || got.TimeLast != "2022-02-22T19:22:22.0000002Z" {
+		t.Errorf("time span = %s .. %s", got.TimeFirst, got.TimeLast)
+	}
+	want := []nodeCount{{"9f:6b:de:ce:d8:46", 2}, {"01:02:03:04:05:06", 1}}
+	if len(got.Nodes) != 2 || got.Nodes[0] != want[0] || got.Nodes[1] != want[1] {
+		t.Errorf("nodes = %v, want %v", got.Nodes, want)
+	}
+}
+
+// TestRunStatsText tests the report from a file & --top
+func TestRunStatsText(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(file, []byte(statsSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runStats([]string{"--top", "1", file}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+		t.Fatalf("runStats() = %d, stderr = %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"lines:         9\n",
+		"duplicates:    1\n",
+		"version v7:    3 (33.3%)\n",
+		"time span:     1.0002ms\n",
+		"v7 unordered:  1\n",
+		"v1 nodes:      2\n  9f:6b:de:ce:d8:46  2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("runStats() output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "01:02:03:04:05:06") {
+		t.Error("runStats() --top 1 listed the second node")
+	}
+}
+
+// TestRunStatsErrors tests usage & io failures
+func TestRunStatsErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runStats([]string{"--top", "-1"}, strings.NewReader(""), &stdout, &stderr); code != exitUsage {
+		t.Errorf("runStats(--top -1) = %d, want %d", code, exitUsage)
+	}
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	if code := runStats([]string{missing}, strings.NewReader(""), &stdout, &stderr); code != exitFailure {
+		t.Errorf("runStats(missing) = %d, want %d", code, exitFailure)
+	}
+}
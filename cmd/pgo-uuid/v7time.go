@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// parse RFC 3339 time or unix milliseconds
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return t, fmt.Errorf("time %q is neither RFC 3339 nor unix milliseconds", s)
+	}
+	return t, nil
+}
+
+// write `count` uuid v7 of time `at` to `w` as `out`
+//
+// ids of the same millisecond are sorted before being written, so the
+// output stay in byte order like the one of generate
+//
+// note: unlike generate nothing is streamed, all `count` ids (16 byte
+// each) are held in memory to be sorted, nothing is written till then
+func generateAt(w io.Writer, at time.Time, count int, out output) error {
+	if count < 1 {
+		return fmt.Errorf("count must be at least 1, got %d", count)
+	}
+	if err := out.check(); err != nil {
+		return err
+	}
+
+	ids := make([]pgo.UUID, count)
+	for i := range ids {
+		u, err := pgo.UUIDv7fromTime(at)
+		if err != nil {
+			return err
+		}
+		ids[i] = u
+	}
+	slices.SortFunc(ids, pgo.UUIDcompare)
+
+	bw := bufio.NewWriter(w)
+	for _, u := range ids {
+		if err := out.write(bw, u); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// parse range "from,to" to its min & max uuid v7
+func parseRange(spec string) (pgo.UUID, pgo.UUID, error) {
+	fromStr, toStr, ok := strings.Cut(spec, ",")
+	if !ok {
+		return pgo.UUID{}, pgo.UUID{}, fmt.Errorf("range %q, want from,to", spec)
+	}
+	from, err := parseTime(fromStr)
+	if err != nil {
+		return pgo.UUID{}, pgo.UUID{}, err
+	}
+	to, err := parseTime(toStr)
+	if err != nil {
+		return pgo.UUID{}, pgo.UUID{}, err
+	}
+	return pgo.UUIDv7bounds(from, to)
+}
+
+// write min & max uuid v7 of range "from,to" to `w` as `out`
+func writeRange(w io.Writer, spec string, out output) error {
+	lo, hi, err := parseRange(spec)
+	if err != nil {
+		return err
+	}
+	if err := out.check(); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	out.write(bw, lo)
+	out.write(bw, hi)
+	return bw.Flush()
+}
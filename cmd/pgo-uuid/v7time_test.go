@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// TestParseTime tests RFC 3339 & unix milliseconds input
+func TestParseTime(t *testing.T) {
+	want := time.UnixMilli(1_645_557_742_000)
+	for _, s := range []string{"1645557742000", "2022-02-22T19:22:22Z", "2022-02-22T20:22:22.000+01:00"} {
+		got, err := parseTime(s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseTime(%q) = %s, %v, want %s", s, got, err, want)
+		}
+	}
+	if _, err := parseTime("yesterday"); err == nil {
+		t.Error("parseTime(yesterday) expected error")
+	}
+}
+
+// TestGenerateAt tests time prefix & order of backfilled ids
+func TestGenerateAt(t *testing.T) {
+	var buf bytes.Buffer
+	if err := generateAt(&buf, time.UnixMilli(1_645_557_742_000), 1000, output{format: "canonical"}); err != nil {
+		t.Fatalf("generateAt() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1000 || !slices.IsSorted(lines) {
+		t.Fatalf("generateAt() wrote %d lines, sorted %v", len(lines), slices.IsSorted(lines))
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "017f22e2-79b0-7") {
+			t.Fatalf("generateAt() = %s, want prefix 017f22e2-79b0-7", line)
+		}
+	}
+
+	if err := generateAt(&buf, time.UnixMilli(-1), 1, output{format: "canonical"}); err == nil {
+		t.Error("generateAt() before epoch expected error")
+	}
+}
+
+// TestWriteRange tests boundary ids of a range
+func TestWriteRange(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeRange(&buf, "2022-02-22T19:22:22Z,1645557743000", output{format: "canonical"}); err != nil {
+		t.Fatalf("writeRange() error = %v", err)
+	}
+	want := "017f22e2-79b0-7000-8000-000000000000\n017f22e2-7d98-7fff-bfff-ffffffffffff\n"
+	if buf.String() != want {
+		t.Errorf("writeRange() = %q, want %q", buf.String(), want)
+	}
+
+	lines := strings.Fields(buf.String())
+	lo, _ := pgo.UUIDfromString(lines[0])
+	hi, _ := pgo.UUIDfromString(lines[1])
+	u, _ := pgo.UUIDv7fromTime(time.UnixMilli(1_645_557_742_500))
+	if u.Compare(lo) < 0 || u.Compare(hi) > 0 {
+		t.Errorf("%s out of written range", u)
+	}
+
+	for _, spec := range []string{"1645557743000", "1645557743000,1645557742000", "a,b"} {
+		if err := writeRange(&buf, spec, output{format: "canonical"}); err == nil {
+			t.Errorf("writeRange(%q) expected error", spec)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/prothegee/pgo/uuid"
+)
+
+// line checker shared by every input of one run
+type validator struct {
+	version int              // required version, 0 for any
+	seen    *pgo.Map[string] // uuid to "file:line" of its first occurrence
+	out     *bufio.Writer    // per line report
+	lines   int
+	errors  int
+}
+
+// check each line of `r`, `name` is used in the report
+func (v *validator) check(name string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		v.lines++
+		at := name + ":" + strconv.Itoa(n)
+
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			v.report(at, "empty line")
+			continue
+		}
+		u, err := pgo.UUIDfromString(line)
+		if err != nil {
+			v.report(at, fmt.Sprintf("%q: %v", line, err))
+			continue
+		}
+		if v.version != 0 && u.Version() != v.version {
+			v.report(at, fmt.Sprintf("%s: version %d, want %d", line, u.Version(), v.version))
+			continue
+		}
+		if first, ok := v.seen.Get(u); ok {
+			v.report(at, fmt.Sprintf("%s: duplicate of %s", line, first))
+			continue
+		}
+		v.seen.Set(u, at)
+	}
+	return sc.Err()
+}
+
+func (v *validator) report(at, reason string) {
+	v.errors++
+	fmt.Fprintf(v.out, "%s: %s\n", at, reason)
+}
+
+// parse --version value, "7" or "v7"
+func parseVersion(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "v"))
+	if err != nil || n < 1 || n > 8 {
+		return 0, fmt.Errorf("version %q is not 1 to 8", s)
+	}
+	return n, nil
+}
+
+// pgo-uuid validate [--version N] [file...]
+//
+// check one uuid per line from the files, or stdin when none (or "-"),
+// report each bad line as file:line: reason, exit 1 if any
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", "[--version N] [file...]", stderr)
+	version := fs.String("version", "", "required uuid version, 1 to 8")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	bw := bufio.NewWriter(stdout)
+	defer bw.Flush()
+	v := &validator{seen: pgo.NewMap[string](0), out: bw}
+	if *version != "" {
+		n, err := parseVersion(*version)
+		if err != nil {
+			return fail(stderr, "validate", exitUsage, err)
+		}
+		v.version = n
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		var err error
+		if name == "-" {
+			err = v.check("stdin", stdin)
+		} else {
+			err = checkFile(v, name)
+		}
+		if err != nil {
+			bw.Flush()
+			return fail(stderr, "validate", exitFailure, err)
+		}
+	}
+
+	bw.Flush()
+	fmt.Fprintf(stderr, "%d lines, %d errors\n", v.lines, v.errors)
+	if v.errors > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func checkFile(v *validator, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return v.check(name, f)
+}
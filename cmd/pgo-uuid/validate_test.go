@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunValidate tests per line reasons, duplicates across inputs & exit code
+func TestRunValidate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ids.txt")
+	content := "017f22e2-79b0-7cc3-98c4-dc0c0c07398f\n919108f7-52d1-4320-9bac-f847db4148a8\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdin := strings.NewReader(strings.Join([]string{
+		"0190a1b2-c3d4-7001-98c4-dc0c0c07398f",
+		"",
+		"not-a-uuid",
+		"{017F22E2-79B0-7CC3-98C4-DC0C0C07398F}",
+		"x919108f7-52d1-4320-9bac-f847db4148a8x",
+		"(017f22e2-79b0-7cc3-98c4-dc0c0c07398f>",
+	}, "\n"))
+
+	var stdout, stderr bytes.Buffer
+	code := runValidate([]string{"--version", "v7", file, "-"}, stdin, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("runValidate() = %d, want 1", code)
+	}
+
+	want := []string{
+		file + ":2: 919108f7-52d1-4320-9bac-f847db4148a8: version 4, want 7",
+		"stdin:2: empty line",
+		`stdin:3: "not-a-uuid": wrong uuid length: 10`,
+		"stdin:4: {017F22E2-79B0-7CC3-98C4-DC0C0C07398F}: duplicate of " + file + ":1",
+		`stdin:5: "x919108f7-52d1-4320-9bac-f847db4148a8x": wrong uuid braces: 'x' 'x'`,
+		`stdin:6: "(017f22e2-79b0-7cc3-98c4-dc0c0c07398f>": wrong uuid braces: '(' '>'`,
+	}
+	if got := strings.Split(strings.TrimSpace(stdout.String()), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("runValidate() report =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if stderr.String() != "8 lines, 6 errors\n" {
+		t.Errorf("runValidate() summary = %q", stderr.String())
+	}
+}
+
+// TestRunValidateOK tests clean input exit 0
+func TestRunValidateOK(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("919108f7-52d1-4320-9bac-f847db4148a8\n017f22e2-79b0-7cc3-98c4-dc0c0c07398f\n")
+	if code := runValidate(nil, stdin, &stdout, &stderr); code != 0 {
+		t.Errorf("runValidate() = %d, want 0, report = %s", code, stdout.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("runValidate() report = %q, want empty", stdout.String())
+	}
+}
+
+// TestRunValidateUsage tests bad version & missing file
+func TestRunValidateUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"--version", "9"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("runValidate(--version 9) = %d, want 2", code)
+	}
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	if code := runValidate([]string{missing}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Errorf("runValidate(missing) = %d, want 1", code)
+	}
+}
@@ -3,16 +3,19 @@ set -e  # fail fast if any command fails
 
 go test -v \
     ./cmd/... \
+    ./snowflake/... \
     ./uuid/... \
     ./utility/... ;
 
 go test -bench=. \
     -benchmem \
     ./cmd/... \
+    ./snowflake/... \
     ./uuid/... \
     ./utility/... ;
 
 go test -race \
     ./cmd/... \
+    ./snowflake/... \
     ./uuid/... \
     ./utility/... ;
@@ -0,0 +1,207 @@
+package pgo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	uuid "github.com/prothegee/pgo/uuid"
+)
+
+// --------------------------------------------------------- //
+
+// twitter snowflake style 64-bit id generator
+//
+// layout, most significant bit first:
+//
+//	1 bit sign (always 0) | timestamp | worker id | sequence
+//
+// timestamp is milliseconds since `Epoch` and take the
+// 63 - WorkerBits - SequenceBits remaining bits
+type SnowflakeGenerator struct {
+	Mtx          sync.Mutex
+	Epoch        time.Time
+	WorkerID     int64
+	WorkerBits   uint8
+	SequenceBits uint8
+	LastMillis   int64 // milliseconds since Epoch of last id
+	Sequence     int64
+}
+
+// decoded snowflake id
+type SnowflakeParts struct {
+	Time     time.Time
+	WorkerID int64
+	Sequence int64
+}
+
+const (
+	DefaultWorkerBits   = uint8(10)
+	DefaultSequenceBits = uint8(12)
+
+	// keep at least 41 bit (~69 years) for the timestamp
+	maxWorkerAndSequenceBits = 22
+)
+
+// twitter epoch, 2010-11-04 01:42:54.657 UTC
+var DefaultEpoch = time.UnixMilli(1288834974657).UTC()
+
+// clock source, swapped by tests
+var timeNow = time.Now
+
+// create snowflake generator
+//
+// params:
+//
+//	epoch time.Time - start of the timestamp, must not be in the future
+//	workerID int64 - this worker, 0 to 2^workerBits-1
+//	workerBits uint8 - bits for the worker id
+//	sequenceBits uint8 - bits for the per millisecond sequence
+//
+// return: *SnowflakeGenerator, error - generator, err||nil
+func NewSnowflakeGenerator(epoch time.Time, workerID int64, workerBits, sequenceBits uint8) (*SnowflakeGenerator, error) {
+	if sequenceBits == 0 {
+		return nil, fmt.Errorf("sequence bits must be at least 1")
+	}
+	if int(workerBits)+int(sequenceBits) > maxWorkerAndSequenceBits {
+		return nil, fmt.Errorf("worker bits + sequence bits must not exceed %d, got %d", maxWorkerAndSequenceBits, int(workerBits)+int(sequenceBits))
+	}
+	if workerID < 0 || workerID >= int64(1)<<workerBits {
+		return nil, fmt.Errorf("worker id %d out of range for %d bit", workerID, workerBits)
+	}
+	if epoch.After(timeNow()) {
+		return nil, fmt.Errorf("epoch %s is in the future", epoch.Format(time.RFC3339))
+	}
+
+	return &SnowflakeGenerator{
+		Epoch:        epoch,
+		WorkerID:     workerID,
+		WorkerBits:   workerBits,
+		SequenceBits: sequenceBits,
+		LastMillis:   0,
+		Sequence:     0,
+	}, nil
+}
+
+// generate next id
+//
+// clock regression (time backward) keep using the last millisecond
+// so ids never go backward, sequence overflow wait till the next
+// millisecond, same as the v1 clock seq overflow
+//
+// return: int64, error - id, err||nil
+func (g *SnowflakeGenerator) NewID() (int64, error) {
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	maxSequence := int64(1)<<g.SequenceBits - 1
+	now := g.sinceEpoch()
+
+	switch {
+	case now <= g.LastMillis:
+		// same millisecond or clock regression - increment sequence
+		g.Sequence = (g.Sequence + 1) & maxSequence
+		if g.Sequence == 0 {
+			// overflow sequence, wait till the clock pass the last millisecond
+			for now <= g.LastMillis {
+				time.Sleep(100 * time.Microsecond)
+				now = g.sinceEpoch()
+			}
+			g.LastMillis = now
+		}
+
+	default:
+		// forward millisecond - reset sequence
+		g.LastMillis = now
+		g.Sequence = 0
+	}
+
+	timestampBits := 63 - g.WorkerBits - g.SequenceBits
+	if g.LastMillis >= int64(1)<<timestampBits {
+		return 0, fmt.Errorf("snowflake timestamp overflow %d bit, epoch %s too old", timestampBits, g.Epoch.Format(time.RFC3339))
+	}
+
+	return g.LastMillis<<(g.WorkerBits+g.SequenceBits) |
+		g.WorkerID<<g.SequenceBits |
+		g.Sequence, nil
+}
+
+// decode `id` generated with the same layout
+//
+// params:
+//
+//	id int64 - snowflake id
+//
+// return: SnowflakeParts, error - parts, err||nil
+func (g *SnowflakeGenerator) Decode(id int64) (SnowflakeParts, error) {
+	if id < 0 {
+		return SnowflakeParts{}, fmt.Errorf("snowflake id cannot be negative: %d", id)
+	}
+
+	millis := id >> (g.WorkerBits + g.SequenceBits)
+	return SnowflakeParts{
+		Time:     g.Epoch.Add(time.Duration(millis) * time.Millisecond),
+		WorkerID: (id >> g.SequenceBits) & (int64(1)<<g.WorkerBits - 1),
+		Sequence: id & (int64(1)<<g.SequenceBits - 1),
+	}, nil
+}
+
+func (g *SnowflakeGenerator) sinceEpoch() int64 {
+	return timeNow().Sub(g.Epoch).Milliseconds()
+}
+
+// --------------------------------------------------------- //
+
+// embed snowflake `id` in a uuid v8
+//
+// the 64 bit id fill custom_a (48 bit), custom_b (12 bit) and the
+// low 4 bit of the first custom_c byte, the remaining bits stay zero,
+// so uuid byte order follow the id order
+//
+// params:
+//
+//	id int64 - snowflake id
+//
+// return: uuid.UUID, error - uuid v8, err||nil
+func SnowflakeToUUIDv8(id int64) (uuid.UUID, error) {
+	if id < 0 {
+		return uuid.UUID{}, fmt.Errorf("snowflake id cannot be negative: %d", id)
+	}
+
+	v := uint64(id)
+	var b [16]byte
+	uuid.PutUint48(b[0:6], v>>16)
+	b[6] = byte(v>>12) & 0x0F
+	b[7] = byte(v >> 4)
+	b[8] = byte(v) & 0x0F
+	return uuid.UUIDv8(b), nil
+}
+
+// extract snowflake id from uuid v8 made by SnowflakeToUUIDv8
+//
+// params:
+//
+//	u uuid.UUID - uuid v8
+//
+// return: int64, error - snowflake id, err||nil
+func SnowflakeFromUUIDv8(u uuid.UUID) (int64, error) {
+	if u.Version() != 8 || u[8]&0xC0 != 0x80 {
+		return 0, fmt.Errorf("not a uuid v8: %s", u)
+	}
+	if u[8]&0x30 != 0 {
+		return 0, fmt.Errorf("uuid v8 does not carry a snowflake id: %s", u)
+	}
+	for _, b := range u[9:] {
+		if b != 0 {
+			return 0, fmt.Errorf("uuid v8 does not carry a snowflake id: %s", u)
+		}
+	}
+
+	v := uint64(u[0])<<56 | uint64(u[1])<<48 | uint64(u[2])<<40 |
+		uint64(u[3])<<32 | uint64(u[4])<<24 | uint64(u[5])<<16 |
+		uint64(u[6]&0x0F)<<12 | uint64(u[7])<<4 | uint64(u[8]&0x0F)
+	if v>>63 != 0 {
+		return 0, fmt.Errorf("uuid v8 snowflake id overflow int64: %s", u)
+	}
+	return int64(v), nil
+}
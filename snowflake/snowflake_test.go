@@ -0,0 +1,232 @@
+package pgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	uuid "github.com/prothegee/pgo/uuid"
+)
+
+// helper to drive the generator clock, restored on cleanup
+func withClock(t *testing.T, now func() time.Time) {
+	t.Helper()
+	orig := timeNow
+	t.Cleanup(func() { timeNow = orig })
+	timeNow = now
+}
+
+// TestNewSnowflakeGenerator tests configuration validation
+func TestNewSnowflakeGenerator(t *testing.T) {
+	tests := []struct {
+		name         string
+		epoch        time.Time
+		workerID     int64
+		workerBits   uint8
+		sequenceBits uint8
+		wantErr      bool
+	}{
+		{"default layout", DefaultEpoch, 1023, DefaultWorkerBits, DefaultSequenceBits, false},
+		{"no worker bits", DefaultEpoch, 0, 0, 12, false},
+		{"no sequence bits", DefaultEpoch, 0, 10, 0, true},
+		{"too many bits", DefaultEpoch, 0, 12, 11, true},
+		{"worker id too big", DefaultEpoch, 1024, 10, 12, true},
+		{"negative worker id", DefaultEpoch, -1, 10, 12, true},
+		{"future epoch", time.Now().Add(time.Hour), 0, 10, 12, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSnowflakeGenerator(tt.epoch, tt.workerID, tt.workerBits, tt.sequenceBits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSnowflakeGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSnowflakeMonotonicAndDecode tests ids increase and decode back
+func TestSnowflakeMonotonicAndDecode(t *testing.T) {
+	g, err := NewSnowflakeGenerator(DefaultEpoch, 42, DefaultWorkerBits, DefaultSequenceBits)
+	if err != nil {
+		t.Fatalf("NewSnowflakeGenerator() error = %v", err)
+	}
+
+	start := time.Now().Truncate(time.Millisecond)
+	var last int64
+	for i := 0; i < 10_000; i++ {
+		id, err := g.NewID()
+		if err != nil {
+			t.Fatalf("NewID() error = %v", err)
+		}
+		if id <= last {
+			t.Fatalf("NewID() = %d not greater than %d", id, last)
+		}
+		last = id
+
+		parts, err := g.Decode(id)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if parts.WorkerID != 42 {
+			t.Fatalf("Decode().WorkerID = %d, want 42", parts.WorkerID)
+		}
+		if parts.Time.Before(start) || parts.Time.After(time.Now()) {
+			t.Fatalf("Decode().Time = %s out of [%s, now]", parts.Time, start)
+		}
+	}
+}
+
+// TestSnowflakeSequenceOverflow tests waiting for the next millisecond
+func TestSnowflakeSequenceOverflow(t *testing.T) {
+	base := time.Now()
+	var mu sync.Mutex
+	calls := 0
+	// clock stuck on one millisecond for the first few reads
+	withClock(t, func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls <= 5 {
+			return base
+		}
+		return base.Add(time.Millisecond)
+	})
+
+	g, _ := NewSnowflakeGenerator(DefaultEpoch, 0, 0, 1) // 2 ids per millisecond
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		id, err := g.NewID()
+		if err != nil {
+			t.Fatalf("NewID() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	p0, _ := g.Decode(ids[0])
+	p1, _ := g.Decode(ids[1])
+	p2, _ := g.Decode(ids[2])
+	if p0.Sequence != 0 || p1.Sequence != 1 || p2.Sequence != 0 {
+		t.Errorf("sequences = %d,%d,%d, want 0,1,0", p0.Sequence, p1.Sequence, p2.Sequence)
+	}
+	if !p2.Time.After(p1.Time) {
+		t.Errorf("overflow id time %s not after %s", p2.Time, p1.Time)
+	}
+}
+
+// TestSnowflakeClockRegression tests ids keep increasing when time go backward
+func TestSnowflakeClockRegression(t *testing.T) {
+	now := time.Now()
+	withClock(t, func() time.Time { return now })
+
+	g, _ := NewSnowflakeGenerator(DefaultEpoch, 1, DefaultWorkerBits, DefaultSequenceBits)
+	first, _ := g.NewID()
+
+	now = now.Add(-time.Second)
+	second, err := g.NewID()
+	if err != nil {
+		t.Fatalf("NewID() error = %v", err)
+	}
+	if second <= first {
+		t.Errorf("NewID() after regression = %d, not greater than %d", second, first)
+	}
+}
+
+// TestSnowflakeConcurrent tests concurrent generation uniqueness
+func TestSnowflakeConcurrent(t *testing.T) {
+	g, _ := NewSnowflakeGenerator(DefaultEpoch, 7, DefaultWorkerBits, DefaultSequenceBits)
+
+	const numGoroutines = 50
+	const numIDsPerGoroutine = 200
+	var wg sync.WaitGroup
+	ids := make(chan int64, numGoroutines*numIDsPerGoroutine)
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numIDsPerGoroutine; j++ {
+				id, err := g.NewID()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				ids <- id
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("Duplicate snowflake id: %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+// TestSnowflakeUUIDv8 tests conversion to & from uuid v8
+func TestSnowflakeUUIDv8(t *testing.T) {
+	g, _ := NewSnowflakeGenerator(DefaultEpoch, 5, DefaultWorkerBits, DefaultSequenceBits)
+
+	var prev uuid.UUID
+	for i := 0; i < 1000; i++ {
+		id, _ := g.NewID()
+		u, err := SnowflakeToUUIDv8(id)
+		if err != nil {
+			t.Fatalf("SnowflakeToUUIDv8() error = %v", err)
+		}
+		if u.Version() != 8 {
+			t.Fatalf("SnowflakeToUUIDv8() version = %d, want 8", u.Version())
+		}
+		if i > 0 && !prev.Less(u) {
+			t.Fatalf("uuid v8 order differ from id order: %s >= %s", prev, u)
+		}
+		prev = u
+
+		back, err := SnowflakeFromUUIDv8(u)
+		if err != nil {
+			t.Fatalf("SnowflakeFromUUIDv8() error = %v", err)
+		}
+		if back != id {
+			t.Fatalf("SnowflakeFromUUIDv8() = %d, want %d", back, id)
+		}
+	}
+
+	// edge values
+	for _, id := range []int64{0, 1, 1<<63 - 1} {
+		u, _ := SnowflakeToUUIDv8(id)
+		if back, err := SnowflakeFromUUIDv8(u); err != nil || back != id {
+			t.Errorf("round trip %d = %d, %v", id, back, err)
+		}
+	}
+
+	if _, err := SnowflakeToUUIDv8(-1); err == nil {
+		t.Error("SnowflakeToUUIDv8(-1) expected error")
+	}
+}
+
+// TestSnowflakeFromUUIDv8Invalid tests rejection of foreign uuid
+func TestSnowflakeFromUUIDv8Invalid(t *testing.T) {
+	v4, _ := uuid.UUIDfromString("919108f7-52d1-4320-9bac-f847db4148a8")
+	rfcV8, _ := uuid.UUIDfromString("2489e9ad-2ee2-8e00-8ec9-32d5f69181c0")
+
+	for _, u := range []uuid.UUID{v4, rfcV8, {}} {
+		if _, err := SnowflakeFromUUIDv8(u); err == nil {
+			t.Errorf("SnowflakeFromUUIDv8(%s) expected error", u)
+		}
+	}
+}
+
+func BenchmarkSnowflakeNewID(b *testing.B) {
+	g, _ := NewSnowflakeGenerator(DefaultEpoch, 1, DefaultWorkerBits, DefaultSequenceBits)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.NewID(); err != nil {
+			b.Fatalf("NewID() error = %v", err)
+		}
+	}
+}
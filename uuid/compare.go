@@ -0,0 +1,126 @@
+package pgo
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// --------------------------------------------------------- //
+
+// compare `u` with `o` byte by byte
+//
+// params:
+//
+//	o UUID - other uuid
+//
+// return: int - -1 if u < o, 0 if u == o, +1 if u > o
+func (u UUID) Compare(o UUID) int {
+	return bytes.Compare(u[:], o[:])
+}
+
+// report whether `u` sort before `o` in byte order
+func (u UUID) Less(o UUID) bool {
+	return u.Compare(o) < 0
+}
+
+// report whether `u` & `o` hold the same 16 bytes
+func (u UUID) Equal(o UUID) bool {
+	return u == o
+}
+
+// byte order comparator, compatible with slices.SortFunc
+//
+// note: byte order equal generation order for v6 & v7,
+// use UUIDcompareByTime for v1
+//
+// params:
+//
+//	a UUID - left
+//	b UUID - right
+//
+// return: int - -1 if a < b, 0 if a == b, +1 if a > b
+func UUIDcompare(a, b UUID) int {
+	return a.Compare(b)
+}
+
+// time aware comparator, compatible with slices.SortFunc
+//
+// v1 store time_low first so the byte order doesn't follow
+// the generation order, this comparator extract the embedded
+// timestamp of v1/v6/v7 and compare it first, then fall back
+// to byte order for ties & non time based versions
+//
+// params:
+//
+//	a UUID - left
+//	b UUID - right
+//
+// return: int - -1 if a < b, 0 if a == b, +1 if a > b
+func UUIDcompareByTime(a, b UUID) int {
+	ta, oka := timeOrderKey(a)
+	tb, okb := timeOrderKey(b)
+
+	switch {
+	case oka && okb:
+		if ta < tb {
+			return -1
+		}
+		if ta > tb {
+			return 1
+		}
+		// same tick, v1/v6 clock seq decide the order
+		if ca, cb := timeOrderSeq(a), timeOrderSeq(b); ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	case oka:
+		// time based sort before non time based
+		return -1
+	case okb:
+		return 1
+	}
+
+	return a.Compare(b)
+}
+
+// --------------------------------------------------------- //
+
+// get embedded timestamp as 100-ns intervals since 1582-10-15
+//
+// return: uint64, bool - key, true if `u` is time based (v1/v6/v7)
+func timeOrderKey(u UUID) (uint64, bool) {
+	switch u[6] >> 4 {
+	case 1, 6:
+		return gregorianTimestamp(u), true
+	case 7:
+		ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+			uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+		return ms*10_000 + gregorianOffset, true // millisecond to 100-ns
+	}
+	return 0, false
+}
+
+// get clock seq for v1/v6, 0 otherwise
+func timeOrderSeq(u UUID) uint16 {
+	switch u[6] >> 4 {
+	case 1, 6:
+		return binary.BigEndian.Uint16(u[8:10]) & clockSeqMask
+	}
+	return 0
+}
+
+// get 60-bit gregorian timestamp of v1/v6
+func gregorianTimestamp(u UUID) uint64 {
+	if u[6]>>4 == 6 {
+		timeHigh := uint64(binary.BigEndian.Uint32(u[0:4]))
+		timeMid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		timeLow := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+		return timeHigh<<28 | timeMid<<12 | timeLow
+	}
+	timeLow := uint64(binary.BigEndian.Uint32(u[0:4]))
+	timeMid := uint64(binary.BigEndian.Uint16(u[4:6]))
+	timeHi := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+	return timeHi<<48 | timeMid<<32 | timeLow
+}
@@ -0,0 +1,168 @@
+package pgo
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestUUIDCompare tests Compare, Less & Equal
+func TestUUIDCompare(t *testing.T) {
+	a, _ := UUIDfromString("00000000-0000-0000-0000-000000000001")
+	b, _ := UUIDfromString("00000000-0000-0000-0000-000000000002")
+	c, _ := UUIDfromString("ffffffff-0000-0000-0000-000000000000")
+
+	tests := []struct {
+		name  string
+		left  UUID
+		right UUID
+		want  int
+	}{
+		{"equal", a, a, 0},
+		{"less last byte", a, b, -1},
+		{"greater last byte", b, a, 1},
+		{"first byte wins", c, b, 1},
+		{"nil less than any", UUID{}, a, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.left.Compare(tt.right); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+			if got := UUIDcompare(tt.left, tt.right); got != tt.want {
+				t.Errorf("UUIDcompare() = %d, want %d", got, tt.want)
+			}
+			if got := tt.left.Less(tt.right); got != (tt.want < 0) {
+				t.Errorf("Less() = %v, want %v", got, tt.want < 0)
+			}
+			if got := tt.left.Equal(tt.right); got != (tt.want == 0) {
+				t.Errorf("Equal() = %v, want %v", got, tt.want == 0)
+			}
+		})
+	}
+}
+
+// TestUUIDv6ByteOrderIsGenerationOrder tests v6 byte order follow generation order
+func TestUUIDv6ByteOrderIsGenerationOrder(t *testing.T) {
+	g, err := NewUUIDv1Generator()
+	if err != nil {
+		t.Fatalf("NewUUIDv1Generator() error = %v", err)
+	}
+
+	const numUUIDs = 10_000
+	ids := make([]UUID, 0, numUUIDs)
+	for i := 0; i < numUUIDs; i++ {
+		s, err := g.NewV6()
+		if err != nil {
+			t.Fatalf("NewV6() error = %v", err)
+		}
+		u, err := UUIDfromString(s)
+		if err != nil {
+			t.Fatalf("UUIDfromString(%q) error = %v", s, err)
+		}
+		ids = append(ids, u)
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if !ids[i-1].Less(ids[i]) {
+			t.Fatalf("v6 #%d not greater than #%d: %x <= %x", i, i-1, ids[i], ids[i-1])
+		}
+	}
+}
+
+// TestUUIDv7ByteOrderIsGenerationOrder tests v7 byte order follow generation order
+func TestUUIDv7ByteOrderIsGenerationOrder(t *testing.T) {
+	g, err := NewUUIDGeneratorV7()
+	if err != nil {
+		t.Fatalf("NewUUIDGeneratorV7() error = %v", err)
+	}
+
+	const numUUIDs = 1000
+	ids := make([]UUID, 0, numUUIDs)
+	for i := 0; i < numUUIDs; i++ {
+		s, err := g.NewV7()
+		if err != nil {
+			t.Fatalf("NewV7() error = %v", err)
+		}
+		u, err := UUIDfromString(s)
+		if err != nil {
+			t.Fatalf("UUIDfromString(%q) error = %v", s, err)
+		}
+		ids = append(ids, u)
+	}
+
+	shuffled := slices.Clone(ids)
+	slices.Reverse(shuffled)
+	slices.SortFunc(shuffled, UUIDcompare)
+	if !slices.Equal(ids, shuffled) {
+		t.Error("sorted v7 differ from generation order")
+	}
+}
+
+// TestUUIDcompareByTime tests the time aware comparator on v1
+func TestUUIDcompareByTime(t *testing.T) {
+	g, err := NewUUIDv1Generator()
+	if err != nil {
+		t.Fatalf("NewUUIDv1Generator() error = %v", err)
+	}
+
+	const numUUIDs = 1000
+	ids := make([]UUID, 0, numUUIDs)
+	for i := 0; i < numUUIDs; i++ {
+		s, err := g.NewV1()
+		if err != nil {
+			t.Fatalf("NewV1() error = %v", err)
+		}
+		u, _ := UUIDfromString(s)
+		ids = append(ids, u)
+	}
+
+	if !slices.IsSortedFunc(ids, UUIDcompareByTime) {
+		t.Error("v1 generation order is not sorted by UUIDcompareByTime")
+	}
+
+	shuffled := slices.Clone(ids)
+	slices.Reverse(shuffled)
+	slices.SortFunc(shuffled, UUIDcompareByTime)
+	if !slices.Equal(ids, shuffled) {
+		t.Error("sorted v1 differ from generation order")
+	}
+}
+
+// TestUUIDcompareByTimeMixed tests the time aware comparator across versions
+func TestUUIDcompareByTimeMixed(t *testing.T) {
+	// same instant 2022-02-22 19:22:22 UTC (RFC 9562 appendix A)
+	v1, _ := UUIDfromString("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6, _ := UUIDfromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	v7, _ := UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	v4, _ := UUIDfromString("919108f7-52d1-4320-9bac-f847db4148a8")
+	later, _ := UUIDfromString("c232ab01-9414-11ec-b3c8-9f6bdeced846")
+
+	tests := []struct {
+		name  string
+		left  UUID
+		right UUID
+		want  int
+	}{
+		{"v1 before later v1", v1, later, -1},
+		{"v6 before later v1", v6, later, -1},
+		{"time based before v4", v7, v4, -1},
+		{"v4 after time based", v4, v1, 1},
+		{"same uuid", v4, v4, 0},
+	}
+
+	// v1 & v6 of the same instant carry the same timestamp
+	k1, _ := timeOrderKey(v1)
+	k6, _ := timeOrderKey(v6)
+	if k1 != k6 {
+		t.Errorf("v1 timestamp %d != v6 timestamp %d", k1, k6)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UUIDcompareByTime(tt.left, tt.right); got != tt.want {
+				t.Errorf("UUIDcompareByTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
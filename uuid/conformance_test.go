@@ -0,0 +1,234 @@
+package pgo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// helper to replay a fixed clock & entropy stream, restored on cleanup
+func withClockAndEntropy(t *testing.T, now time.Time, entropy []byte) {
+	t.Helper()
+	origNow, origRand := timeNow, randReader
+	t.Cleanup(func() { timeNow, randReader = origNow, origRand })
+
+	timeNow = func() time.Time { return now }
+	randReader = bytes.NewReader(entropy)
+}
+
+// 2022-02-22 19:22:22 UTC as 60-bit gregorian timestamp (RFC 9562 appendix A)
+const rfcGregorianTimestamp = uint64(0x1EC9414C232AB00)
+
+var rfcNode = [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+
+func rfcGregorianTime() time.Time {
+	return time.Unix(0, int64(rfcGregorianTimestamp-gregorianOffset)*100)
+}
+
+func mustUUID(t *testing.T, s string) UUID {
+	t.Helper()
+	u, err := UUIDfromString(s)
+	if err != nil {
+		t.Fatalf("UUIDfromString(%q) error = %v", s, err)
+	}
+	return u
+}
+
+// TestRFC9562VectorV1 tests appendix A.1
+func TestRFC9562VectorV1(t *testing.T) {
+	withClockAndEntropy(t, rfcGregorianTime(), []byte{0x33, 0xc8}) // clock seq 0x33C8
+
+	g := &UUIDv1Generator{Node: rfcNode}
+	got, err := g.NewV1()
+	if err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+	if want := "c232ab00-9414-11ec-b3c8-9f6bdeced846"; got != want {
+		t.Errorf("NewV1() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV3 tests appendix A.2
+func TestRFC9562VectorV3(t *testing.T) {
+	got := UUIDv3(NamespaceDNS, "www.example.com")
+	if want := mustUUID(t, "5df41881-3aed-3515-88a7-2f4a814cf09e"); got != want {
+		t.Errorf("UUIDv3() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV4 tests appendix A.3
+func TestRFC9562VectorV4(t *testing.T) {
+	entropy := []byte{
+		0x91, 0x91, 0x08, 0xf7, 0x52, 0xd1, 0x33, 0x20,
+		0x5b, 0xac, 0xf8, 0x47, 0xdb, 0x41, 0x48, 0xa8,
+	}
+	withClockAndEntropy(t, time.Now(), entropy)
+
+	got, err := UUIDv4asString()
+	if err != nil {
+		t.Fatalf("UUIDv4asString() error = %v", err)
+	}
+	if want := "919108f7-52d1-4320-9bac-f847db4148a8"; got != want {
+		t.Errorf("UUIDv4asString() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV5 tests appendix A.4
+func TestRFC9562VectorV5(t *testing.T) {
+	got := UUIDv5(NamespaceDNS, "www.example.com")
+	if want := mustUUID(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2"); got != want {
+		t.Errorf("UUIDv5() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV6 tests appendix A.5
+func TestRFC9562VectorV6(t *testing.T) {
+	withClockAndEntropy(t, rfcGregorianTime(), []byte{0x33, 0xc8}) // clock seq 0x33C8
+
+	g := &UUIDv1Generator{Node: rfcNode}
+	got, err := g.NewV6()
+	if err != nil {
+		t.Fatalf("NewV6() error = %v", err)
+	}
+	if want := "1ec9414c-232a-6b00-b3c8-9f6bdeced846"; got != want {
+		t.Errorf("NewV6() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV7 tests appendix A.6
+func TestRFC9562VectorV7(t *testing.T) {
+	const unixMillis = 0x017F22E279B0
+
+	// rand_b 0x18C4DC0C0C07398F, variant overwrite the top 2 bits
+	entropy := []byte{0x18, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f, 0x00, 0x00}
+	withClockAndEntropy(t, time.UnixMilli(unixMillis), entropy)
+
+	// the generator fill rand_a with its counter, preload it with 0xCC3
+	g := &UUIDGeneratorV7{LastMillis: unixMillis, Counter: 0xCC3}
+	got, err := g.NewV7()
+	if err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+	if want := "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; got != want {
+		t.Errorf("NewV7() = %s, want %s", got, want)
+	}
+}
+
+// TestRFC9562VectorV8 tests appendix B.1 & B.2
+func TestRFC9562VectorV8(t *testing.T) {
+	t.Run("time based", func(t *testing.T) {
+		custom := [16]byte{
+			0x24, 0x89, 0xe9, 0xad, 0x2e, 0xe2, // custom_a
+			0x0e, 0x00, // custom_b
+			0x0e, 0xc9, 0x32, 0xd5, 0xf6, 0x91, 0x81, 0xc0, // custom_c
+		}
+		got := UUIDv8(custom)
+		if want := mustUUID(t, "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0"); got != want {
+			t.Errorf("UUIDv8() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("name based sha-256", func(t *testing.T) {
+		sum := sha256.Sum256(append(NamespaceDNS[:], "www.example.com"...))
+		got := UUIDv8([16]byte(sum[:16]))
+		if want := mustUUID(t, "5c146b14-3c52-8afd-938a-375d0df1fbf6"); got != want {
+			t.Errorf("UUIDv8() = %s, want %s", got, want)
+		}
+	})
+}
+
+// TestUUIDfromBytesURN tests the urn form is accepted like UUIDfromString
+func TestUUIDfromBytesURN(t *testing.T) {
+	const s = "urn:uuid:c232ab00-9414-11ec-b3c8-9f6bdeced846"
+	got, err := UUIDfromBytes([]byte(s))
+	if err != nil {
+		t.Fatalf("UUIDfromBytes() error = %v", err)
+	}
+	if want := mustUUID(t, s); got != want {
+		t.Errorf("UUIDfromBytes() = %s, want %s", got, want)
+	}
+}
+
+// --------------------------------------------------------- //
+
+var parseSeeds = []string{
+	"c232ab00-9414-11ec-b3c8-9f6bdeced846",
+	"C232AB00-9414-11EC-B3C8-9F6BDECED846",
+	"{919108f7-52d1-4320-9bac-f847db4148a8}",
+	"urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+	"017f22e279b07cc398c4dc0c0c07398f",
+	"00000000-0000-0000-0000-000000000000",
+	"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	"c232ab00x9414-11ec-b3c8-9f6bdeced846",
+	"urn:uuid:",
+	"",
+	"x919108f7-52d1-4320-9bac-f847db4148a8x",
+	"(017f22e2-79b0-7cc3-98c4-dc0c0c07398f>",
+}
+
+// check that parsed `u` survive a format/parse round trip
+func checkRoundTrip(t *testing.T, input string, u UUID) {
+	t.Helper()
+	s := u.String()
+	back, err := UUIDfromString(s)
+	if err != nil {
+		t.Fatalf("UUIDfromString(%q) of formatted %q error = %v", s, input, err)
+	}
+	if back != u {
+		t.Fatalf("round trip of %q: %s != %s", input, back, u)
+	}
+
+	// accepted input must be one of the rendered forms, any case
+	forms := []string{s, hex.EncodeToString(u[:]), "{" + s + "}", "urn:uuid:" + s}
+	if !slices.ContainsFunc(forms, func(f string) bool { return strings.EqualFold(f, input) }) {
+		t.Fatalf("accepted %q is none of the forms of %s", input, u)
+	}
+}
+
+func FuzzUUIDfromString(f *testing.F) {
+	for _, s := range parseSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		u, err := UUIDfromString(s)
+		if err != nil {
+			return
+		}
+		checkRoundTrip(t, s, u)
+
+		// bytes parser must agree with the string parser
+		ub, err := UUIDfromBytes([]byte(s))
+		if err != nil {
+			t.Fatalf("UUIDfromBytes(%q) error = %v, UUIDfromString accepted", s, err)
+		}
+		if ub != u {
+			t.Fatalf("UUIDfromBytes(%q) = %s, UUIDfromString = %s", s, ub, u)
+		}
+	})
+}
+
+func FuzzUUIDfromBytes(f *testing.F) {
+	for _, s := range parseSeeds {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		u, err := UUIDfromBytes(b)
+		if err != nil {
+			return
+		}
+		s := string(b)
+		checkRoundTrip(t, s, u)
+
+		us, err := UUIDfromString(s)
+		if err != nil {
+			t.Fatalf("UUIDfromString(%q) error = %v, UUIDfromBytes accepted", s, err)
+		}
+		if us != u {
+			t.Fatalf("UUIDfromString(%q) = %s, UUIDfromBytes = %s", s, us, u)
+		}
+	})
+}
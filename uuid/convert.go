@@ -0,0 +1,82 @@
+package pgo
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// --------------------------------------------------------- //
+
+// reorder uuid v1 timestamp to v6 layout (RFC 9562 section 5.6)
+//
+// clock seq & node are kept, so converting back give the same v1
+//
+// params:
+//
+//	u UUID - uuid v1
+//
+// return: UUID, error - uuid v6, err||nil
+func UUIDv1toV6(u UUID) (UUID, error) {
+	if u.Version() != 1 {
+		return u, fmt.Errorf("uuid %s is version %d, want 1", u, u.Version())
+	}
+	ts := gregorianTimestamp(u)
+
+	res := u
+	binary.BigEndian.PutUint32(res[0:4], uint32(ts>>28))
+	binary.BigEndian.PutUint16(res[4:6], uint16(ts>>12))
+	binary.BigEndian.PutUint16(res[6:8], uint16(ts&0x0FFF)|0x6000)
+	return res, nil
+}
+
+// reorder uuid v6 timestamp back to v1 layout
+//
+// params:
+//
+//	u UUID - uuid v6
+//
+// return: UUID, error - uuid v1, err||nil
+func UUIDv6toV1(u UUID) (UUID, error) {
+	if u.Version() != 6 {
+		return u, fmt.Errorf("uuid %s is version %d, want 6", u, u.Version())
+	}
+	ts := gregorianTimestamp(u)
+
+	res := u
+	binary.BigEndian.PutUint32(res[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(res[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(res[6:8], uint16(ts>>48)&0x0FFF|0x1000)
+	return res, nil
+}
+
+// get Microsoft GUID byte order of `u`
+//
+// the first 3 fields (4, 2 & 2 byte) are little endian, as written by
+// .NET Guid.ToByteArray & SQL Server uniqueidentifier
+func (u UUID) GUIDBytes() [16]byte {
+	return swapGUID(u)
+}
+
+// get uuid from 16 byte in Microsoft GUID byte order
+//
+// params:
+//
+//	b []byte - mixed endian source, see GUIDBytes
+//
+// return: UUID, error - uuid, err||nil
+func UUIDfromGUIDBytes(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != len(u) {
+		return u, fmt.Errorf("wrong guid length: %d", len(b))
+	}
+	copy(u[:], b)
+	return swapGUID(u), nil
+}
+
+// swap endianness of the 3 first fields, its own inverse
+func swapGUID(u UUID) UUID {
+	u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+	u[4], u[5] = u[5], u[4]
+	u[6], u[7] = u[7], u[6]
+	return u
+}
@@ -0,0 +1,61 @@
+package pgo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestUUIDv1V6 tests RFC 9562 v1 & v6 vectors map to each other
+func TestUUIDv1V6(t *testing.T) {
+	v1 := mustUUID(t, "c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := mustUUID(t, "1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+
+	got6, err := UUIDv1toV6(v1)
+	if err != nil || got6 != v6 {
+		t.Errorf("UUIDv1toV6() = %s, %v, want %s", got6, err, v6)
+	}
+	got1, err := UUIDv6toV1(v6)
+	if err != nil || got1 != v1 {
+		t.Errorf("UUIDv6toV1() = %s, %v, want %s", got1, err, v1)
+	}
+
+	// generated ones keep their time
+	g, _ := NewUUIDv1Generator()
+	for i := 0; i < 100; i++ {
+		s, _ := g.NewV1()
+		u := mustUUID(t, s)
+		conv, _ := UUIDv1toV6(u)
+		back, _ := UUIDv6toV1(conv)
+		t1, _ := u.Time()
+		t6, _ := conv.Time()
+		if back != u || !t1.Equal(t6) {
+			t.Fatalf("round trip %s -> %s -> %s", u, conv, back)
+		}
+	}
+
+	if _, err := UUIDv1toV6(v6); err == nil {
+		t.Error("UUIDv1toV6(v6) expected error")
+	}
+	if _, err := UUIDv6toV1(v1); err == nil {
+		t.Error("UUIDv6toV1(v1) expected error")
+	}
+}
+
+// TestGUIDBytes tests mixed endian layout of .NET Guid.ToByteArray
+func TestGUIDBytes(t *testing.T) {
+	u := mustUUID(t, "00112233-4455-6677-8899-aabbccddeeff")
+	want, _ := hex.DecodeString("33221100554477668899aabbccddeeff")
+
+	b := u.GUIDBytes()
+	if !bytes.Equal(b[:], want) {
+		t.Errorf("GUIDBytes() = %x, want %x", b, want)
+	}
+	back, err := UUIDfromGUIDBytes(b[:])
+	if err != nil || back != u {
+		t.Errorf("UUIDfromGUIDBytes() = %s, %v, want %s", back, err, u)
+	}
+	if _, err := UUIDfromGUIDBytes(want[:15]); err == nil {
+		t.Error("UUIDfromGUIDBytes() short input expected error")
+	}
+}
@@ -0,0 +1,100 @@
+package pgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// --------------------------------------------------------- //
+
+// uuid variant, the layout family selected by the high bits of byte [8]
+type Variant byte
+
+const (
+	VariantNCS       = Variant(0) // 0xxx, reserved NCS backward compatibility
+	VariantRFC9562   = Variant(1) // 10xx, RFC 4122 / RFC 9562
+	VariantMicrosoft = Variant(2) // 110x, reserved Microsoft GUID
+	VariantFuture    = Variant(3) // 111x, reserved future definition
+)
+
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC9562:
+		return "RFC 9562"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	}
+	return fmt.Sprintf("variant%d", byte(v))
+}
+
+// get uuid variant
+func (u UUID) Variant() Variant {
+	switch {
+	case u[8]&0x80 == 0x00:
+		return VariantNCS
+	case u[8]&0xC0 == 0x80:
+		return VariantRFC9562
+	case u[8]&0xE0 == 0xC0:
+		return VariantMicrosoft
+	}
+	return VariantFuture
+}
+
+// get embedded creation time of v1, v6 (100ns precision) & v7 (ms precision)
+//
+// return: time.Time, bool - time, false if `u` has no full timestamp
+func (u UUID) Time() (time.Time, bool) {
+	switch u.Version() {
+	case 1, 6:
+		// split in seconds & 100ns, the 60-bit range overflow time.Duration
+		ts := int64(gregorianTimestamp(u)) - int64(gregorianOffset)
+		sec, rem := ts/1e7, ts%1e7
+		if rem < 0 {
+			sec, rem = sec-1, rem+1e7
+		}
+		return time.Unix(sec, rem*100), true
+	case 7:
+		ms := int64(binary.BigEndian.Uint64(u[0:8]) >> 16)
+		return time.UnixMilli(ms), true
+	}
+	return time.Time{}, false
+}
+
+// get 14-bit clock sequence of v1 & v6
+//
+// return: uint16, bool - clock seq, false for other versions
+func (u UUID) ClockSequence() (uint16, bool) {
+	switch u.Version() {
+	case 1, 6:
+		return timeOrderSeq(u), true
+	}
+	return 0, false
+}
+
+// get 48-bit node of v1, v2 & v6, MAC address or random multicast
+//
+// return: net.HardwareAddr, bool - node, false for other versions
+func (u UUID) NodeID() (net.HardwareAddr, bool) {
+	switch u.Version() {
+	case 1, 2, 6:
+		return append(net.HardwareAddr(nil), u[10:]...), true
+	}
+	return nil, false
+}
+
+// get 12-bit rand_a of v7, the per millisecond counter for uuid made by
+// UUIDGeneratorV7, plain random for other generators
+//
+// return: uint16, bool - counter, false for other versions
+func (u UUID) Counter() (uint16, bool) {
+	if u.Version() != 7 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint16(u[6:8]) & 0x0FFF, true
+}
@@ -0,0 +1,92 @@
+package pgo
+
+import (
+	"testing"
+	"time"
+)
+
+// TestUUIDVariant tests every variant range of byte [8]
+func TestUUIDVariant(t *testing.T) {
+	tests := []struct {
+		b8   byte
+		want Variant
+		name string
+	}{
+		{0x00, VariantNCS, "NCS"},
+		{0x7F, VariantNCS, "NCS"},
+		{0x80, VariantRFC9562, "RFC 9562"},
+		{0xBF, VariantRFC9562, "RFC 9562"},
+		{0xC0, VariantMicrosoft, "Microsoft"},
+		{0xDF, VariantMicrosoft, "Microsoft"},
+		{0xE0, VariantFuture, "Future"},
+		{0xFF, VariantFuture, "Future"},
+	}
+	for _, tt := range tests {
+		var u UUID
+		u[8] = tt.b8
+		if got := u.Variant(); got != tt.want || got.String() != tt.name {
+			t.Errorf("Variant() of 0x%02x = %s, want %s", tt.b8, got, tt.name)
+		}
+	}
+}
+
+// TestUUIDFields tests time, clock seq, node & counter of RFC 9562 vectors
+func TestUUIDFields(t *testing.T) {
+	v1 := mustUUID(t, "c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := mustUUID(t, "1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	v7 := mustUUID(t, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	v4 := mustUUID(t, "919108f7-52d1-4320-9bac-f847db4148a8")
+
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, u := range []UUID{v1, v6, v7} {
+		got, ok := u.Time()
+		if !ok || !got.Equal(want) {
+			t.Errorf("%s Time() = %s, %v, want %s", u, got.UTC(), ok, want)
+		}
+	}
+	if _, ok := v4.Time(); ok {
+		t.Error("v4 Time() should not be ok")
+	}
+
+	for _, u := range []UUID{v1, v6} {
+		if seq, ok := u.ClockSequence(); !ok || seq != 0x33C8 {
+			t.Errorf("%s ClockSequence() = 0x%x, %v, want 0x33c8", u, seq, ok)
+		}
+		if node, ok := u.NodeID(); !ok || node.String() != "9f:6b:de:ce:d8:46" {
+			t.Errorf("%s NodeID() = %s, %v", u, node, ok)
+		}
+	}
+	if _, ok := v7.ClockSequence(); ok {
+		t.Error("v7 ClockSequence() should not be ok")
+	}
+	if _, ok := v4.NodeID(); ok {
+		t.Error("v4 NodeID() should not be ok")
+	}
+
+	if c, ok := v7.Counter(); !ok || c != 0xCC3 {
+		t.Errorf("Counter() = 0x%x, %v, want 0xcc3", c, ok)
+	}
+	if _, ok := v1.Counter(); ok {
+		t.Error("v1 Counter() should not be ok")
+	}
+}
+
+// TestUUIDTimeRange tests v1 & v6 time at both ends of the 60-bit timestamp
+func TestUUIDTimeRange(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Time
+	}{
+		{"00000000-0000-1000-8000-000000000000", time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"00000000-0000-6000-8000-000000000000", time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"00000001-0000-1000-8000-000000000000", time.Date(1582, 10, 15, 0, 0, 0, 100, time.UTC)},
+		{"ffffffff-ffff-1fff-bfff-ffffffffffff", time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)},
+		{"ffffffff-ffff-6fff-bfff-ffffffffffff", time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := mustUUID(t, tt.s).Time()
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("%s Time() = %s, %v, want %s", tt.s, got.UTC(), ok, tt.want)
+		}
+	}
+}
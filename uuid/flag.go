@@ -0,0 +1,54 @@
+package pgo
+
+import (
+	"strings"
+)
+
+// --------------------------------------------------------- //
+
+// implement flag.Value, parse any form accepted by UUIDfromString
+//
+//	var id UUID
+//	flag.Var(&id, "id", "record id")
+func (u *UUID) Set(s string) error {
+	parsed, err := UUIDfromString(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// repeatable flag of uuid, each occurrence may hold a comma separated list
+//
+//	var ids UUIDList
+//	flag.Var(&ids, "id", "record id, repeatable")
+type UUIDList []UUID
+
+// implement flag.Value, comma separated canonical form
+func (l *UUIDList) String() string {
+	if l == nil {
+		return ""
+	}
+	parts := make([]string, len(*l))
+	for i, u := range *l {
+		parts[i] = u.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// implement flag.Value, append every uuid of `s` to the list
+//
+// nothing is appended when one of them is invalid
+func (l *UUIDList) Set(s string) error {
+	var parsed []UUID
+	for _, part := range strings.Split(s, ",") {
+		var u UUID
+		if err := u.Set(strings.TrimSpace(part)); err != nil {
+			return err
+		}
+		parsed = append(parsed, u)
+	}
+	*l = append(*l, parsed...)
+	return nil
+}
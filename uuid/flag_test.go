@@ -0,0 +1,59 @@
+package pgo
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+// TestUUIDFlag tests UUID & UUIDList as command line flags
+func TestUUIDFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var id UUID
+	var ids UUIDList
+	fs.Var(&id, "id", "single id")
+	fs.Var(&ids, "ids", "repeatable ids")
+
+	err := fs.Parse([]string{
+		"-id", "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"-ids", "919108f7-52d1-4320-9bac-f847db4148a8, {017f22e2-79b0-7cc3-98c4-dc0c0c07398f}",
+		"-ids", "1ec9414c-232a-6b00-b3c8-9f6bdeced846",
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := id.String(); got != "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" {
+		t.Errorf("-id = %s", got)
+	}
+	want := "919108f7-52d1-4320-9bac-f847db4148a8,017f22e2-79b0-7cc3-98c4-dc0c0c07398f,1ec9414c-232a-6b00-b3c8-9f6bdeced846"
+	if got := ids.String(); got != want {
+		t.Errorf("-ids = %s, want %s", got, want)
+	}
+}
+
+// TestUUIDFlagInvalid tests rejection without partial update
+func TestUUIDFlagInvalid(t *testing.T) {
+	id := NamespaceDNS
+	if err := id.Set("not-a-uuid"); err == nil {
+		t.Error("Set() expected error")
+	}
+	if id != NamespaceDNS {
+		t.Error("Set() modified value on error")
+	}
+
+	ids := UUIDList{NamespaceURL}
+	if err := ids.Set(NamespaceDNS.String() + ",bad"); err == nil {
+		t.Error("UUIDList.Set() expected error")
+	}
+	if len(ids) != 1 {
+		t.Errorf("UUIDList.Set() appended %d on error", len(ids)-1)
+	}
+
+	var empty *UUIDList
+	if empty.String() != "" {
+		t.Error("nil UUIDList.String() not empty")
+	}
+}
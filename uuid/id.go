@@ -0,0 +1,181 @@
+package pgo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------- //
+
+// optional interface of entity type `T` in ID[T] to set a string prefix
+//
+// e.g.
+//
+//	type User struct{ ... }
+//	func (User) IDPrefix() string { return "usr" }
+//
+//	ID[User] is written as "usr_0190e4c8-5f6e-7c3a-9d1e-2b4f6a8c0e12"
+type IDPrefixer interface {
+	IDPrefix() string
+}
+
+// uuid typed by the entity it identify
+//
+// ID[User] & ID[Order] are distinct types, so passing one where
+// the other is expected is a compile error instead of a bug
+//
+// note: zero value is the nil uuid
+type ID[T any] struct {
+	uuid UUID
+}
+
+// get prefix declared by `T`, empty if none
+func idPrefix[T any]() string {
+	var zero T
+	if p, ok := any(&zero).(IDPrefixer); ok {
+		return p.IDPrefix()
+	}
+	return ""
+}
+
+// wrap existing uuid `u` as ID[T]
+func IDfromUUID[T any](u UUID) ID[T] {
+	return ID[T]{uuid: u}
+}
+
+// generate ID[T] from uuid v4
+func NewIDv4[T any]() (ID[T], error) {
+	u, err := UUIDv4()
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return ID[T]{uuid: u}, nil
+}
+
+// generate ID[T] from uuid v7
+func NewIDv7[T any]() (ID[T], error) {
+	u, err := UUIDv7()
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return ID[T]{uuid: u}, nil
+}
+
+// parse ID[T] from string `s`
+//
+// if `T` declare a prefix, `s` must start with "prefix_",
+// the rest accept any form of UUIDfromString
+//
+// params:
+//
+//	s string - source
+//
+// return: ID[T], error - id, err||nil
+func ParseID[T any](s string) (ID[T], error) {
+	if prefix := idPrefix[T](); prefix != "" {
+		rest, ok := strings.CutPrefix(s, prefix+"_")
+		if !ok {
+			return ID[T]{}, fmt.Errorf("wrong id prefix, want %q", prefix+"_")
+		}
+		s = rest
+	}
+
+	u, err := UUIDfromString(s)
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return ID[T]{uuid: u}, nil
+}
+
+// get underlying uuid
+func (id ID[T]) UUID() UUID {
+	return id.uuid
+}
+
+// report whether id is the nil uuid
+func (id ID[T]) IsNil() bool {
+	return id.uuid == UUID{}
+}
+
+// get canonical form, with "prefix_" if `T` declare one
+func (id ID[T]) String() string {
+	if prefix := idPrefix[T](); prefix != "" {
+		return prefix + "_" + id.uuid.String()
+	}
+	return id.uuid.String()
+}
+
+// implements encoding.TextMarshaler
+func (id ID[T]) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// implements encoding.TextUnmarshaler
+func (id *ID[T]) UnmarshalText(b []byte) error {
+	res, err := ParseID[T](string(b))
+	if err != nil {
+		return err
+	}
+	*id = res
+	return nil
+}
+
+// implements json.Marshaler
+func (id ID[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// implements json.Unmarshaler, null leave id untouched
+func (id *ID[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("id must be a json string: %w", err)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// implements driver.Valuer
+//
+// note: stored as plain canonical uuid without prefix,
+// so it fit native uuid columns
+func (id ID[T]) Value() (driver.Value, error) {
+	return id.uuid.String(), nil
+}
+
+// implements sql.Scanner
+//
+// accept string/[]byte in any UUIDfromString form (prefix optional),
+// 16 raw bytes, or nil for the nil uuid
+func (id *ID[T]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = ID[T]{}
+		return nil
+	case string:
+		return id.scanString(v)
+	case []byte:
+		if len(v) == 16 {
+			copy(id.uuid[:], v)
+			return nil
+		}
+		return id.scanString(string(v))
+	}
+	return fmt.Errorf("cannot scan %T into id", src)
+}
+
+func (id *ID[T]) scanString(s string) error {
+	if prefix := idPrefix[T](); prefix != "" {
+		s = strings.TrimPrefix(s, prefix+"_")
+	}
+	u, err := UUIDfromString(s)
+	if err != nil {
+		return err
+	}
+	id.uuid = u
+	return nil
+}
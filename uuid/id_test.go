@@ -0,0 +1,178 @@
+package pgo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+type testUser struct{}
+
+func (testUser) IDPrefix() string { return "usr" }
+
+type testOrder struct{}
+
+// compile time interface checks
+var (
+	_ encoding.TextMarshaler   = ID[testUser]{}
+	_ encoding.TextUnmarshaler = (*ID[testUser])(nil)
+	_ json.Marshaler           = ID[testUser]{}
+	_ json.Unmarshaler         = (*ID[testUser])(nil)
+	_ driver.Valuer            = ID[testUser]{}
+	_ sql.Scanner              = (*ID[testUser])(nil)
+)
+
+// TestNewID tests typed id generation
+func TestNewID(t *testing.T) {
+	v4, err := NewIDv4[testOrder]()
+	if err != nil {
+		t.Fatalf("NewIDv4() error = %v", err)
+	}
+	if v4.UUID().Version() != 4 || v4.IsNil() {
+		t.Errorf("NewIDv4() = %s, want non nil v4", v4)
+	}
+
+	v7, err := NewIDv7[testUser]()
+	if err != nil {
+		t.Fatalf("NewIDv7() error = %v", err)
+	}
+	if v7.UUID().Version() != 7 || v7.IsNil() {
+		t.Errorf("NewIDv7() = %s, want non nil v7", v7)
+	}
+}
+
+// TestIDString tests formatting with & without prefix
+func TestIDString(t *testing.T) {
+	u, _ := UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+
+	if got, want := IDfromUUID[testUser](u).String(), "usr_017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; got != want {
+		t.Errorf("ID[testUser].String() = %q, want %q", got, want)
+	}
+	if got, want := IDfromUUID[testOrder](u).String(), "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; got != want {
+		t.Errorf("ID[testOrder].String() = %q, want %q", got, want)
+	}
+}
+
+// TestParseID tests parsing with prefix validation
+func TestParseID(t *testing.T) {
+	const canonical = "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+
+	tests := []struct {
+		name    string
+		parse   func(string) (UUID, error)
+		input   string
+		wantErr bool
+	}{
+		{"prefixed", parseAsUUID[testUser], "usr_" + canonical, false},
+		{"prefixed hex form", parseAsUUID[testUser], "usr_017f22e279b07cc398c4dc0c0c07398f", false},
+		{"missing prefix", parseAsUUID[testUser], canonical, true},
+		{"wrong prefix", parseAsUUID[testUser], "ord_" + canonical, true},
+		{"prefix only", parseAsUUID[testUser], "usr_", true},
+		{"no prefix type", parseAsUUID[testOrder], canonical, false},
+		{"no prefix type given prefix", parseAsUUID[testOrder], "usr_" + canonical, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != canonical {
+				t.Errorf("ParseID() = %s, want %s", got, canonical)
+			}
+		})
+	}
+}
+
+func parseAsUUID[T any](s string) (UUID, error) {
+	id, err := ParseID[T](s)
+	return id.UUID(), err
+}
+
+// TestIDJSON tests json round trip inside a struct
+func TestIDJSON(t *testing.T) {
+	type order struct {
+		ID    ID[testOrder] `json:"id"`
+		Buyer ID[testUser]  `json:"buyer"`
+		Ref   *ID[testUser] `json:"ref"`
+	}
+
+	in := order{}
+	in.ID, _ = NewIDv7[testOrder]()
+	in.Buyer, _ = NewIDv7[testUser]()
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var out order
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", b, err)
+	}
+	if out.ID != in.ID || out.Buyer != in.Buyer || out.Ref != nil {
+		t.Errorf("json round trip = %+v, want %+v", out, in)
+	}
+
+	// map keys use the text interfaces
+	m := map[ID[testUser]]int{in.Buyer: 1}
+	b, err = json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal(map) error = %v", err)
+	}
+	var back map[ID[testUser]]int
+	if err := json.Unmarshal(b, &back); err != nil || back[in.Buyer] != 1 {
+		t.Errorf("json map round trip = %v, %v", back, err)
+	}
+
+	var bad order
+	if err := json.Unmarshal([]byte(`{"buyer":"ord_017f22e2-79b0-7cc3-98c4-dc0c0c07398f"}`), &bad); err == nil {
+		t.Error("json.Unmarshal() expected prefix error")
+	}
+	if err := json.Unmarshal([]byte(`{"buyer":42}`), &bad); err == nil {
+		t.Error("json.Unmarshal() expected type error")
+	}
+}
+
+// TestIDSQL tests driver.Valuer & sql.Scanner
+func TestIDSQL(t *testing.T) {
+	const canonical = "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+	u, _ := UUIDfromString(canonical)
+	id := IDfromUUID[testUser](u)
+
+	v, err := id.Value()
+	if err != nil || v != canonical {
+		t.Errorf("Value() = %v, %v, want %q without prefix", v, err, canonical)
+	}
+
+	tests := []struct {
+		name    string
+		src     any
+		want    UUID
+		wantErr bool
+	}{
+		{"string", canonical, u, false},
+		{"prefixed string", "usr_" + canonical, u, false},
+		{"text bytes", []byte(canonical), u, false},
+		{"raw bytes", u[:], u, false},
+		{"nil", nil, UUID{}, false},
+		{"bad string", "nope", UUID{}, true},
+		{"unsupported type", 42, UUID{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IDfromUUID[testUser](UUID{0xff})
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.UUID() != tt.want {
+				t.Errorf("Scan() = %s, want %s", got.UUID(), tt.want)
+			}
+		})
+	}
+}
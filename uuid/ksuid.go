@@ -0,0 +1,193 @@
+package pgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// --------------------------------------------------------- //
+
+// 160 bit (20 byte) K-Sortable Unique IDentifier
+//
+// 32-bit big endian timestamp (seconds since KSUIDepoch) followed
+// by 128-bit random payload, written as 27 char base62
+type KSUID [20]byte
+
+const (
+	// KSUID timestamp start, 2014-05-13 16:53:20 UTC
+	KSUIDepoch = int64(1400000000)
+
+	ksuidTimestampLen = 4
+	ksuidPayloadLen   = 16
+	ksuidStringLen    = 27
+
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// lookup table of base62Alphabet, 0xFF if invalid
+var base62Values = func() [256]byte {
+	var res [256]byte
+	for i := range res {
+		res[i] = 0xFF
+	}
+	for i := 0; i < len(base62Alphabet); i++ {
+		res[base62Alphabet[i]] = byte(i)
+	}
+	return res
+}()
+
+// generate KSUID for the current time
+//
+// return: KSUID, error - ksuid, err||nil
+func NewKSUID() (KSUID, error) {
+	return KSUIDwithTime(timeNow())
+}
+
+// generate KSUID for time `t` with a random payload
+//
+// params:
+//
+//	t time.Time - between KSUIDepoch & KSUIDepoch + 2^32 seconds
+//
+// return: KSUID, error - ksuid, err||nil
+func KSUIDwithTime(t time.Time) (KSUID, error) {
+	var k KSUID
+
+	ts := t.Unix() - KSUIDepoch
+	if ts < 0 || ts > 0xFFFFFFFF {
+		return k, fmt.Errorf("time %s out of ksuid range", t.UTC().Format(time.RFC3339))
+	}
+	binary.BigEndian.PutUint32(k[:ksuidTimestampLen], uint32(ts))
+
+	if err := readRandom(k[ksuidTimestampLen:]); err != nil {
+		return KSUID{}, err
+	}
+	return k, nil
+}
+
+// parse 27 char base62 KSUID
+//
+// params:
+//
+//	s string - source
+//
+// return: KSUID, error - ksuid, err||nil
+func KSUIDfromString(s string) (KSUID, error) {
+	var k KSUID
+	if len(s) != ksuidStringLen {
+		return k, fmt.Errorf("wrong ksuid length: %d", len(s))
+	}
+
+	// 160 bit as five 32 bit words, most significant first
+	var words [5]uint32
+	for i := 0; i < len(s); i++ {
+		v := base62Values[s[i]]
+		if v == 0xFF {
+			return k, fmt.Errorf("wrong ksuid character %q at %d", s[i], i)
+		}
+
+		// words = words*62 + v
+		carry := uint64(v)
+		for j := len(words) - 1; j >= 0; j-- {
+			x := uint64(words[j])*62 + carry
+			words[j] = uint32(x)
+			carry = x >> 32
+		}
+		if carry != 0 {
+			return k, fmt.Errorf("ksuid overflow 160 bit: %q", s)
+		}
+	}
+
+	for i, w := range words {
+		binary.BigEndian.PutUint32(k[i*4:], w)
+	}
+	return k, nil
+}
+
+// get KSUID from 20 raw bytes
+//
+// params:
+//
+//	b []byte - source
+//
+// return: KSUID, error - ksuid, err||nil
+func KSUIDfromBytes(b []byte) (KSUID, error) {
+	var k KSUID
+	if len(b) != len(k) {
+		return k, fmt.Errorf("wrong ksuid length: %d", len(b))
+	}
+	copy(k[:], b)
+	return k, nil
+}
+
+// get 27 char base62 form
+func (k KSUID) String() string {
+	var words [5]uint32
+	for i := range words {
+		words[i] = binary.BigEndian.Uint32(k[i*4:])
+	}
+
+	var buf [ksuidStringLen]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		// words, rem = words/62, words%62
+		var rem uint64
+		for j := range words {
+			x := rem<<32 | uint64(words[j])
+			words[j] = uint32(x / 62)
+			rem = x % 62
+		}
+		buf[i] = base62Alphabet[rem]
+	}
+	return string(buf[:])
+}
+
+// get seconds since KSUIDepoch
+func (k KSUID) Timestamp() uint32 {
+	return binary.BigEndian.Uint32(k[:ksuidTimestampLen])
+}
+
+// get creation time, second precision
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(k.Timestamp())+KSUIDepoch, 0)
+}
+
+// get 16 byte random payload
+func (k KSUID) Payload() []byte {
+	return bytes.Clone(k[ksuidTimestampLen:])
+}
+
+// report whether `k` is the all zero KSUID
+func (k KSUID) IsNil() bool {
+	return k == KSUID{}
+}
+
+// compare `k` with `o`, byte order equal time order
+//
+// return: int - -1 if k < o, 0 if k == o, +1 if k > o
+func (k KSUID) Compare(o KSUID) int {
+	return bytes.Compare(k[:], o[:])
+}
+
+// get the KSUID right after `k`, wrap around after the max value
+func (k KSUID) Next() KSUID {
+	for i := len(k) - 1; i >= 0; i-- {
+		k[i]++
+		if k[i] != 0 {
+			break
+		}
+	}
+	return k
+}
+
+// get the KSUID right before `k`, wrap around before the nil value
+func (k KSUID) Prev() KSUID {
+	for i := len(k) - 1; i >= 0; i-- {
+		k[i]--
+		if k[i] != 0xFF {
+			break
+		}
+	}
+	return k
+}
@@ -0,0 +1,163 @@
+package pgo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestKSUIDVectors tests known string & raw pairs
+func TestKSUIDVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		raw  string
+	}{
+		{"nil", "000000000000000000000000000", "0000000000000000000000000000000000000000"},
+		{"max", "aWgEPTl1tmebfsQzFP4bxwgy80V", "ffffffffffffffffffffffffffffffffffffffff"},
+		{"sample", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "0669f7efb5a1cd34b5f99d1154fb6853345c9735"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := hex.DecodeString(tt.raw)
+
+			k, err := KSUIDfromString(tt.str)
+			if err != nil {
+				t.Fatalf("KSUIDfromString() error = %v", err)
+			}
+			if !bytes.Equal(k[:], raw) {
+				t.Errorf("KSUIDfromString() = %x, want %s", k[:], tt.raw)
+			}
+
+			kb, err := KSUIDfromBytes(raw)
+			if err != nil {
+				t.Fatalf("KSUIDfromBytes() error = %v", err)
+			}
+			if got := kb.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+}
+
+// TestKSUIDComponents tests timestamp, time & payload accessors
+func TestKSUIDComponents(t *testing.T) {
+	k, _ := KSUIDfromString("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+
+	if got := k.Timestamp(); got != 107608047 {
+		t.Errorf("Timestamp() = %d, want 107608047", got)
+	}
+	if got, want := k.Time().UTC(), time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Time() = %s, want %s", got, want)
+	}
+	if got := hex.EncodeToString(k.Payload()); got != "b5a1cd34b5f99d1154fb6853345c9735" {
+		t.Errorf("Payload() = %s", got)
+	}
+}
+
+// TestNewKSUID tests generation through the injectable clock & entropy
+func TestNewKSUID(t *testing.T) {
+	payload := bytes.Repeat([]byte{0xAB}, 16)
+	withClockAndEntropy(t, time.Unix(KSUIDepoch+107608047, 0), payload)
+
+	k, err := NewKSUID()
+	if err != nil {
+		t.Fatalf("NewKSUID() error = %v", err)
+	}
+	if k.Timestamp() != 107608047 || !bytes.Equal(k.Payload(), payload) {
+		t.Errorf("NewKSUID() = %x", k[:])
+	}
+
+	if _, err := KSUIDwithTime(time.Unix(KSUIDepoch-1, 0)); err == nil {
+		t.Error("KSUIDwithTime() before epoch expected error")
+	}
+}
+
+// TestKSUIDOrdering tests byte & string order follow time order
+func TestKSUIDOrdering(t *testing.T) {
+	base := time.Now()
+	var ids []KSUID
+	for i := 0; i < 100; i++ {
+		k, err := KSUIDwithTime(base.Add(time.Duration(i) * time.Second))
+		if err != nil {
+			t.Fatalf("KSUIDwithTime() error = %v", err)
+		}
+		ids = append(ids, k)
+	}
+
+	if !slices.IsSortedFunc(ids, KSUID.Compare) {
+		t.Error("KSUID byte order differ from time order")
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].String() >= ids[i].String() {
+			t.Fatalf("KSUID string order differ from time order at %d", i)
+		}
+	}
+}
+
+// TestKSUIDNextPrev tests neighbours & wrap around
+func TestKSUIDNextPrev(t *testing.T) {
+	k, _ := NewKSUID()
+	if k.Next().Prev() != k || k.Prev().Next() != k {
+		t.Error("Next & Prev are not inverse")
+	}
+	if k.Next().Compare(k) != 1 {
+		t.Error("Next() not greater")
+	}
+
+	// carry into the timestamp
+	var edge KSUID
+	copy(edge[4:], bytes.Repeat([]byte{0xFF}, 16))
+	next := edge.Next()
+	if next.Timestamp() != 1 || !bytes.Equal(next.Payload(), make([]byte, 16)) {
+		t.Errorf("Next() carry = %x", next[:])
+	}
+
+	maxKSUID, _ := KSUIDfromString("aWgEPTl1tmebfsQzFP4bxwgy80V")
+	if !maxKSUID.Next().IsNil() {
+		t.Error("max.Next() should wrap to nil")
+	}
+	if (KSUID{}).Prev() != maxKSUID {
+		t.Error("nil.Prev() should wrap to max")
+	}
+}
+
+// TestKSUIDfromStringInvalid tests parser rejections
+func TestKSUIDfromStringInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"short", "0ujtsYcgvSTl8PAuAdqWYSMnLO"},
+		{"long", "0ujtsYcgvSTl8PAuAdqWYSMnLOvv"},
+		{"bad char", "0ujtsYcgvSTl8PAuAdqWYSMnLO-"},
+		{"overflow", "aWgEPTl1tmebfsQzFP4bxwgy80W"},
+		{"overflow all z", "zzzzzzzzzzzzzzzzzzzzzzzzzzz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := KSUIDfromString(tt.input); err == nil {
+				t.Errorf("KSUIDfromString(%q) expected error", tt.input)
+			}
+		})
+	}
+
+	if _, err := KSUIDfromBytes(make([]byte, 16)); err == nil {
+		t.Error("KSUIDfromBytes() expected length error")
+	}
+}
+
+func BenchmarkNewKSUIDString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k, err := NewKSUID()
+		if err != nil {
+			b.Fatalf("NewKSUID() error = %v", err)
+		}
+		_ = k.String()
+	}
+}
@@ -0,0 +1,50 @@
+package pgo
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// --------------------------------------------------------- //
+
+// implement slog.LogValuer, log canonical form
+func (u UUID) LogValue() slog.Value {
+	return slog.StringValue(u.String())
+}
+
+// uuid logged without its unique part
+//
+//	slog.Info("login", "user", id.Redacted())
+//
+// log a group of the version and, for the time ordered v6 & v7, the
+// 13 char time prefix (xxxxxxxx-xxxx) enough to correlate by creation
+// time but not to recover the id, other versions log the version only
+type RedactedUUID UUID
+
+// get redacting wrapper of `u` for logging
+func (u UUID) Redacted() RedactedUUID {
+	return RedactedUUID(u)
+}
+
+// implement slog.LogValuer
+func (r RedactedUUID) LogValue() slog.Value {
+	u := UUID(r)
+	attrs := []slog.Attr{slog.Int("version", u.Version())}
+	switch u.Version() {
+	case 6, 7:
+		attrs = append(attrs, slog.String("prefix", u.String()[:13]))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// implement fmt.Stringer, same content as LogValue for plain text logs
+//
+// e.g. "v7:017f22e2-79b0" or "v4"
+func (r RedactedUUID) String() string {
+	u := UUID(r)
+	switch u.Version() {
+	case 6, 7:
+		return "v" + strconv.Itoa(u.Version()) + ":" + u.String()[:13]
+	}
+	return "v" + strconv.Itoa(u.Version())
+}
@@ -0,0 +1,77 @@
+package pgo
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// helper to log `args` as json with no time
+func logJSON(args ...any) string {
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	slog.New(h).Info("m", args...)
+	return strings.TrimSpace(buf.String())
+}
+
+// TestUUIDLogValue tests canonical form in structured logs
+func TestUUIDLogValue(t *testing.T) {
+	u := mustUUID(t, "919108f7-52d1-4320-9bac-f847db4148a8")
+	want := `{"msg":"m","id":"919108f7-52d1-4320-9bac-f847db4148a8"}`
+	if got := logJSON("id", u); got != want {
+		t.Errorf("log = %s, want %s", got, want)
+	}
+}
+
+// TestRedactedUUID tests only version & time prefix are logged
+func TestRedactedUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		uuid    string
+		wantLog string
+		wantStr string
+	}{
+		{
+			"v7",
+			"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+			`{"msg":"m","id":{"version":7,"prefix":"017f22e2-79b0"}}`,
+			"v7:017f22e2-79b0",
+		},
+		{
+			"v6",
+			"1ec9414c-232a-6b00-b3c8-9f6bdeced846",
+			`{"msg":"m","id":{"version":6,"prefix":"1ec9414c-232a"}}`,
+			"v6:1ec9414c-232a",
+		},
+		{
+			"v4",
+			"919108f7-52d1-4320-9bac-f847db4148a8",
+			`{"msg":"m","id":{"version":4}}`,
+			"v4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mustUUID(t, tt.uuid).Redacted()
+			got := logJSON("id", r)
+			if got != tt.wantLog {
+				t.Errorf("log = %s, want %s", got, tt.wantLog)
+			}
+			if r.String() != tt.wantStr {
+				t.Errorf("String() = %s, want %s", r.String(), tt.wantStr)
+			}
+			if strings.Contains(got, tt.uuid[24:]) {
+				t.Errorf("log leak node/random part: %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package pgo
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// --------------------------------------------------------- //
+
+const (
+	// url safe default alphabet of 64 symbols
+	NanoIDalphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	// default length, ~126 bit of randomness with NanoIDalphabet
+	NanoIDsize = 21
+)
+
+// generate random id of NanoIDsize over NanoIDalphabet
+//
+// return: string, error - id, err||nil
+func NanoID() (string, error) {
+	return NanoIDwithAlphabet(NanoIDalphabet, NanoIDsize)
+}
+
+// generate random id of `size` symbols over `alphabet`
+//
+// random bytes are masked to the next power of two of the alphabet
+// size and out of range values are rejected, so every symbol has
+// exactly the same probability (no modulo bias)
+//
+// params:
+//
+//	alphabet string - 2 to 256 distinct bytes
+//	size int - id length, at least 1
+//
+// return: string, error - id, err||nil
+func NanoIDwithAlphabet(alphabet string, size int) (string, error) {
+	if err := validateNanoIDalphabet(alphabet); err != nil {
+		return "", err
+	}
+	if size < 1 {
+		return "", fmt.Errorf("nanoid size must be at least 1, got %d", size)
+	}
+
+	mask := 1<<bits.Len(uint(len(alphabet)-1)) - 1
+
+	// expected random bytes for `size` accepted symbols, with 60% margin
+	// for the rejected ones, so a single read is usually enough
+	step := int(math.Ceil(1.6 * float64(mask) * float64(size) / float64(len(alphabet))))
+	buf := make([]byte, step)
+
+	id := make([]byte, 0, size)
+	for {
+		if err := readRandom(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if i := int(b) & mask; i < len(alphabet) {
+				id = append(id, alphabet[i])
+				if len(id) == size {
+					return string(id), nil
+				}
+			}
+		}
+	}
+}
+
+func validateNanoIDalphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return fmt.Errorf("nanoid alphabet must have 2 to 256 symbols, got %d", len(alphabet))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		if seen[alphabet[i]] {
+			return fmt.Errorf("nanoid alphabet has duplicate symbol %q", alphabet[i])
+		}
+		seen[alphabet[i]] = true
+	}
+	return nil
+}
+
+// --------------------------------------------------------- //
+
+// probability of at least one collision among `count` ids (birthday bound)
+//
+// params:
+//
+//	alphabetSize int - number of symbols
+//	size int - id length
+//	count float64 - number of generated ids
+//
+// return: float64 - probability between 0 and 1
+func NanoIDcollisionProbability(alphabetSize, size int, count float64) float64 {
+	if count < 2 {
+		return 0
+	}
+	space := math.Pow(float64(alphabetSize), float64(size))
+	return -math.Expm1(-count * (count - 1) / (2 * space))
+}
+
+// number of ids after which the collision probability reach `p`
+//
+// params:
+//
+//	alphabetSize int - number of symbols
+//	size int - id length
+//	p float64 - target probability, between 0 and 1
+//
+// return: float64 - id count
+func NanoIDcountForProbability(alphabetSize, size int, p float64) float64 {
+	if p <= 0 {
+		return 0
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+	// solve count*(count-1)/(2*space) = -ln(1-p)
+	space := math.Pow(float64(alphabetSize), float64(size))
+	return (1 + math.Sqrt(1+8*space*-math.Log1p(-p))) / 2
+}
@@ -0,0 +1,147 @@
+package pgo
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNanoID tests default length & alphabet
+func TestNanoID(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := NanoID()
+		if err != nil {
+			t.Fatalf("NanoID() error = %v", err)
+		}
+		if len(id) != NanoIDsize {
+			t.Fatalf("NanoID() length = %d, want %d", len(id), NanoIDsize)
+		}
+		for _, c := range id {
+			if !strings.ContainsRune(NanoIDalphabet, c) {
+				t.Fatalf("NanoID() = %q has symbol %q outside alphabet", id, c)
+			}
+		}
+		if seen[id] {
+			t.Fatalf("Duplicate NanoID found: %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+// TestNanoIDwithAlphabet tests custom alphabet, size & validation
+func TestNanoIDwithAlphabet(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		size     int
+		wantErr  bool
+	}{
+		{"digits", "0123456789", 8, false},
+		{"binary", "01", 64, false},
+		{"power of two", "abcdefghijklmnop", 5, false},
+		{"single symbol", "a", 5, true},
+		{"empty alphabet", "", 5, true},
+		{"duplicate symbol", "abca", 5, true},
+		{"zero size", "abc", 0, true},
+		{"negative size", "abc", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NanoIDwithAlphabet(tt.alphabet, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NanoIDwithAlphabet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(id) != tt.size {
+				t.Errorf("NanoIDwithAlphabet() length = %d, want %d", len(id), tt.size)
+			}
+			if strings.Trim(id, tt.alphabet) != "" {
+				t.Errorf("NanoIDwithAlphabet() = %q has symbol outside %q", id, tt.alphabet)
+			}
+		})
+	}
+}
+
+// TestNanoIDRejection tests out of range bytes are skipped, not folded
+func TestNanoIDRejection(t *testing.T) {
+	// 10 symbols, mask 0x0F: 0x0A..0x0F must be rejected
+	entropy := make([]byte, 64)
+	copy(entropy, []byte{0x0A, 0x01, 0xFF, 0x12, 0x0F, 0x09, 0x03})
+	withClockAndEntropy(t, time.Now(), entropy)
+
+	id, err := NanoIDwithAlphabet("0123456789", 4)
+	if err != nil {
+		t.Fatalf("NanoIDwithAlphabet() error = %v", err)
+	}
+	if id != "1293" {
+		t.Errorf("NanoIDwithAlphabet() = %q, want %q", id, "1293")
+	}
+}
+
+// TestNanoIDUniform tests symbols are equally likely
+func TestNanoIDUniform(t *testing.T) {
+	const alphabet = "abcdefghij" // not a power of two
+	const total = 200_000
+
+	id, err := NanoIDwithAlphabet(alphabet, total)
+	if err != nil {
+		t.Fatalf("NanoIDwithAlphabet() error = %v", err)
+	}
+
+	counts := make(map[rune]int)
+	for _, c := range id {
+		counts[c]++
+	}
+
+	// chi-square with 9 degrees of freedom, 27.88 is the 0.999 quantile
+	expected := float64(total) / float64(len(alphabet))
+	var chi2 float64
+	for _, c := range alphabet {
+		d := float64(counts[c]) - expected
+		chi2 += d * d / expected
+	}
+	if chi2 > 27.88 {
+		t.Errorf("symbol distribution not uniform, chi2 = %.2f, counts = %v", chi2, counts)
+	}
+}
+
+// TestNanoIDProbability tests the collision helpers
+func TestNanoIDProbability(t *testing.T) {
+	// birthday paradox: 23 people, 365 days
+	if p := NanoIDcollisionProbability(365, 1, 23); math.Abs(p-0.5) > 0.03 {
+		t.Errorf("NanoIDcollisionProbability(365, 1, 23) = %f, want ~0.5", p)
+	}
+	if p := NanoIDcollisionProbability(64, 21, 1); p != 0 {
+		t.Errorf("NanoIDcollisionProbability() of one id = %f, want 0", p)
+	}
+
+	// default nanoid, one billion ids is still far from any collision
+	if p := NanoIDcollisionProbability(len(NanoIDalphabet), NanoIDsize, 1e9); p <= 0 || p > 1e-15 {
+		t.Errorf("NanoIDcollisionProbability(default, 1e9) = %g", p)
+	}
+
+	// helpers are inverse of each other
+	for _, p := range []float64{1e-9, 0.01, 0.5, 0.99} {
+		n := NanoIDcountForProbability(36, 8, p)
+		if got := NanoIDcollisionProbability(36, 8, n); math.Abs(got-p)/p > 1e-3 {
+			t.Errorf("collision probability of %g ids = %g, want %g", n, got, p)
+		}
+	}
+
+	if NanoIDcountForProbability(64, 21, 0) != 0 || !math.IsInf(NanoIDcountForProbability(64, 21, 1), 1) {
+		t.Error("NanoIDcountForProbability() bounds")
+	}
+}
+
+func BenchmarkNanoID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := NanoID(); err != nil {
+			b.Fatalf("NanoID() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package pgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// --------------------------------------------------------- //
+
+// reversible keyed transform between v7 & v4-looking uuid
+//
+// v7 leak its creation time through the 48-bit timestamp, the
+// obfuscator run the 122 payload bits (48-bit timestamp as left
+// half, 12-bit rand_a + 62-bit rand_b as right half) through a
+// small unbalanced feistel network keyed with HMAC-SHA256, then
+// stamp version 4, so the database keep sortable v7 and clients
+// only see opaque ids
+//
+// note: this is obfuscation for exposure, not encryption of secrets
+type Obfuscator struct {
+	key []byte
+}
+
+const (
+	obfuscatorMinKeySize = 16
+	obfuscatorRounds     = 4
+
+	mask48 = uint64(1)<<48 - 1
+	mask62 = uint64(1)<<62 - 1
+)
+
+// create obfuscator from secret `key`
+//
+// params:
+//
+//	key []byte - secret, at least 16 byte
+//
+// return: *Obfuscator, error - obfuscator, err||nil
+func NewObfuscator(key []byte) (*Obfuscator, error) {
+	if len(key) < obfuscatorMinKeySize {
+		return nil, fmt.Errorf("obfuscator key too short: %d byte, need at least %d", len(key), obfuscatorMinKeySize)
+	}
+	return &Obfuscator{key: append([]byte(nil), key...)}, nil
+}
+
+// turn v7 `u` into v4-looking external id
+//
+// params:
+//
+//	u UUID - uuid v7
+//
+// return: UUID, error - external id, err||nil
+func (o *Obfuscator) Encode(u UUID) (UUID, error) {
+	if u.Version() != 7 || u[8]&0xC0 != 0x80 {
+		return UUID{}, fmt.Errorf("obfuscator only encode uuid v7, got version %d", u.Version())
+	}
+
+	left, randA, randB := splitPayload(u)
+	for round := 0; round < obfuscatorRounds; round++ {
+		left, randA, randB = o.round(round, left, randA, randB)
+	}
+	return joinPayload(left, randA, randB, 4), nil
+}
+
+// turn external id from Encode back into the original v7
+//
+// params:
+//
+//	u UUID - external id
+//
+// return: UUID, error - uuid v7, err||nil
+func (o *Obfuscator) Decode(u UUID) (UUID, error) {
+	if u.Version() != 4 || u[8]&0xC0 != 0x80 {
+		return UUID{}, fmt.Errorf("obfuscator only decode uuid v4 layout, got version %d", u.Version())
+	}
+
+	left, randA, randB := splitPayload(u)
+	for round := obfuscatorRounds - 1; round >= 0; round-- {
+		left, randA, randB = o.round(round, left, randA, randB)
+	}
+	return joinPayload(left, randA, randB, 7), nil
+}
+
+// one feistel round, even round mix the right half into the left,
+// odd round mix the left half into the right
+//
+// each round only xor one half with a function of the other one,
+// so applying the same round again undo it
+func (o *Obfuscator) round(round int, left uint64, randA uint16, randB uint64) (uint64, uint16, uint64) {
+	mac := hmac.New(sha256.New, o.key)
+
+	var in [11]byte
+	in[0] = byte(round)
+	if round%2 == 0 {
+		binary.BigEndian.PutUint16(in[1:3], randA)
+		binary.BigEndian.PutUint64(in[3:11], randB)
+		mac.Write(in[:])
+		sum := mac.Sum(nil)
+
+		left ^= binary.BigEndian.Uint64(sum[0:8]) & mask48
+		return left, randA, randB
+	}
+
+	binary.BigEndian.PutUint64(in[3:11], left)
+	mac.Write(in[:])
+	sum := mac.Sum(nil)
+
+	randA ^= binary.BigEndian.Uint16(sum[0:2]) & 0x0FFF
+	randB ^= binary.BigEndian.Uint64(sum[2:10]) & mask62
+	return left, randA, randB
+}
+
+// split the 122 non version/variant bits
+func splitPayload(u UUID) (uint64, uint16, uint64) {
+	left := binary.BigEndian.Uint64(append([]byte{0, 0}, u[0:6]...))
+	randA := binary.BigEndian.Uint16(u[6:8]) & 0x0FFF
+	randB := binary.BigEndian.Uint64(u[8:16]) & mask62
+	return left, randA, randB
+}
+
+// join payload bits back, stamping `version` & the RFC variant
+func joinPayload(left uint64, randA uint16, randB uint64, version byte) UUID {
+	var u UUID
+	PutUint48(u[0:6], left)
+	binary.BigEndian.PutUint16(u[6:8], uint16(version)<<12|randA)
+	binary.BigEndian.PutUint64(u[8:16], randB)
+	u[8] = (u[8] & 0x3F) | 0x80 // 10xxxxxx
+	return u
+}
@@ -0,0 +1,124 @@
+package pgo
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+var obfuscatorTestKey = []byte("0123456789abcdef0123456789abcdef")
+
+// TestObfuscatorRoundTrip tests Encode & Decode are inverse of each other
+func TestObfuscatorRoundTrip(t *testing.T) {
+	o, err := NewObfuscator(obfuscatorTestKey)
+	if err != nil {
+		t.Fatalf("NewObfuscator() error = %v", err)
+	}
+
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for i := 0; i < 1000; i++ {
+		v7, err := UUIDv7()
+		if err != nil {
+			t.Fatalf("UUIDv7() error = %v", err)
+		}
+
+		ext, err := o.Encode(v7)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if ext.Version() != 4 {
+			t.Fatalf("Encode() version = %d, want 4", ext.Version())
+		}
+		if !pattern.MatchString(ext.String()) {
+			t.Fatalf("Encode() = %s, not v4 looking", ext)
+		}
+
+		back, err := o.Decode(ext)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if back != v7 {
+			t.Fatalf("Decode(Encode(%s)) = %s", v7, back)
+		}
+	}
+}
+
+// TestObfuscatorMaskTimestamp tests the timestamp is not visible in the output
+func TestObfuscatorMaskTimestamp(t *testing.T) {
+	o, _ := NewObfuscator(obfuscatorTestKey)
+
+	// same millisecond, consecutive counter
+	a, _ := UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	b, _ := UUIDfromString("017f22e2-79b0-7cc4-98c4-dc0c0c07398f")
+
+	ea, _ := o.Encode(a)
+	eb, _ := o.Encode(b)
+
+	if bytes.Equal(ea[0:6], a[0:6]) {
+		t.Error("Encode() kept the timestamp as is")
+	}
+	if bytes.Equal(ea[0:6], eb[0:6]) {
+		t.Error("Encode() of same millisecond share the same prefix")
+	}
+}
+
+// TestObfuscatorKeyed tests different keys give different ids
+func TestObfuscatorKeyed(t *testing.T) {
+	o1, _ := NewObfuscator(obfuscatorTestKey)
+	o2, _ := NewObfuscator([]byte("another secret key, 16+ byte long"))
+
+	v7, _ := UUIDv7()
+	e1, _ := o1.Encode(v7)
+	e2, _ := o2.Encode(v7)
+	if e1 == e2 {
+		t.Error("different keys produced the same external id")
+	}
+
+	// wrong key decode to a different v7
+	if back, err := o2.Decode(e1); err == nil && back == v7 {
+		t.Error("Decode() with wrong key recovered the original")
+	}
+}
+
+// TestObfuscatorErrors tests invalid key & input
+func TestObfuscatorErrors(t *testing.T) {
+	if _, err := NewObfuscator([]byte("short")); err == nil {
+		t.Error("NewObfuscator() expected error for short key")
+	}
+
+	o, _ := NewObfuscator(obfuscatorTestKey)
+	v4, _ := UUIDfromString("919108f7-52d1-4320-9bac-f847db4148a8")
+	v7, _ := UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+
+	tests := []struct {
+		name string
+		fn   func(UUID) (UUID, error)
+		in   UUID
+	}{
+		{"encode v4", o.Encode, v4},
+		{"encode nil", o.Encode, UUID{}},
+		{"decode v7", o.Decode, v7},
+		{"decode nil", o.Decode, UUID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.fn(tt.in); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func BenchmarkObfuscatorEncode(b *testing.B) {
+	o, _ := NewObfuscator(obfuscatorTestKey)
+	v7, _ := UUIDv7()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := o.Encode(v7); err != nil {
+			b.Fatalf("Encode() error = %v", err)
+		}
+	}
+}
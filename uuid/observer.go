@@ -0,0 +1,142 @@
+package pgo
+
+import (
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// --------------------------------------------------------- //
+
+// receive generator events
+//
+// callbacks are invoked with the generator lock held, implementation
+// must be fast, non blocking & must not call back into the generator
+type Observer interface {
+	// an uuid of `version` was generated
+	Generated(version int)
+
+	// v1/v6 clock sequence exhausted within one 100ns tick,
+	// v2 one of a domain & id within one ~7 minute window
+	ClockSeqOverflow(version int)
+
+	// v7 12-bit counter exhausted within one millisecond
+	CounterOverflow(version int)
+
+	// clock went backward since the previous uuid
+	ClockRegression(version int)
+
+	// generator slept `d` waiting for the clock to move forward
+	Waited(version int, d time.Duration)
+}
+
+// Observer of atomic counters, safe for concurrent use
+//
+// share one Metrics between several generators to aggregate them
+type Metrics struct {
+	generated        [9]atomic.Uint64 // index by version
+	clockSeqOverflow atomic.Uint64
+	counterOverflow  atomic.Uint64
+	clockRegression  atomic.Uint64
+	waitNanos        atomic.Int64
+}
+
+// point in time copy of Metrics
+type MetricsSnapshot struct {
+	Generated        map[string]uint64 `json:"generated"` // key "v1", "v6", ...
+	ClockSeqOverflow uint64            `json:"clock_seq_overflow"`
+	CounterOverflow  uint64            `json:"counter_overflow"`
+	ClockRegression  uint64            `json:"clock_regression"`
+	WaitTime         time.Duration     `json:"wait_time_ns"`
+}
+
+func (m *Metrics) Generated(version int) {
+	if version > 0 && version < len(m.generated) {
+		m.generated[version].Add(1)
+	}
+}
+
+func (m *Metrics) ClockSeqOverflow(int) {
+	m.clockSeqOverflow.Add(1)
+}
+
+func (m *Metrics) CounterOverflow(int) {
+	m.counterOverflow.Add(1)
+}
+
+func (m *Metrics) ClockRegression(int) {
+	m.clockRegression.Add(1)
+}
+
+func (m *Metrics) Waited(_ int, d time.Duration) {
+	m.waitNanos.Add(int64(d))
+}
+
+// get current counter values
+//
+// return: MetricsSnapshot
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	s := MetricsSnapshot{
+		Generated:        make(map[string]uint64),
+		ClockSeqOverflow: m.clockSeqOverflow.Load(),
+		CounterOverflow:  m.counterOverflow.Load(),
+		ClockRegression:  m.clockRegression.Load(),
+		WaitTime:         time.Duration(m.waitNanos.Load()),
+	}
+	for v := 1; v < len(m.generated); v++ {
+		if n := m.generated[v].Load(); n > 0 {
+			s.Generated[fmt.Sprintf("v%d", v)] = n
+		}
+	}
+	return s
+}
+
+// publish the metrics snapshot as expvar `name`
+//
+// the value is computed on each read of /debug/vars
+//
+// params:
+//
+//	name string - expvar name, must not be published yet
+//
+// return: error - err||nil
+func (m *Metrics) PublishExpvar(name string) error {
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any {
+		return m.Snapshot()
+	}))
+	return nil
+}
+
+// --------------------------------------------------------- //
+
+// attach `o` to the global generators used by the UUIDv1, UUIDv2,
+// UUIDv6 & UUIDv7 functions, nil to detach
+//
+// return: error - err||nil
+func SetObserver(o Observer) error {
+	GlobalGeneratorV1Once.Do(func() {
+		GlobalGeneratorV1, GlobalGeneratorV1Err = NewUUIDv1Generator()
+	})
+	if GlobalGeneratorV1Err != nil {
+		return fmt.Errorf("fail to initialize uuid v1: %w", GlobalGeneratorV1Err)
+	}
+	GeneratorV7Once.Do(func() {
+		GeneratorV7, GeneratorV7Err = NewUUIDGeneratorV7()
+	})
+	if GeneratorV7Err != nil {
+		return fmt.Errorf("fail to initialize uuid v7: %w", GeneratorV7Err)
+	}
+
+	GlobalGeneratorV1.Mtx.Lock()
+	GlobalGeneratorV1.Observer = o
+	GlobalGeneratorV1.Mtx.Unlock()
+
+	GeneratorV7.Mtx.Lock()
+	GeneratorV7.Observer = o
+	GeneratorV7.Mtx.Unlock()
+	return nil
+}
@@ -0,0 +1,154 @@
+package pgo
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+// TestObserverV1 tests generated, regression & overflow events of v1 generator
+func TestObserverV1(t *testing.T) {
+	m := &Metrics{}
+	g, err := NewUUIDv1Generator()
+	if err != nil {
+		t.Fatalf("NewUUIDv1Generator() error = %v", err)
+	}
+	g.Observer = m
+
+	for i := 0; i < 10; i++ {
+		if _, err := g.NewV1(); err != nil {
+			t.Fatalf("NewV1() error = %v", err)
+		}
+	}
+	if _, err := g.NewV6(); err != nil {
+		t.Fatalf("NewV6() error = %v", err)
+	}
+
+	// last timestamp in the future, next call is a regression
+	st := g.Snapshot()
+	st.LastTimestamp += uint64(time.Hour / 100)
+	g.Restore(st)
+	if _, err := g.NewV1(); err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+
+	// clock frozen on the last timestamp with the clock seq at its end,
+	// next call overflow & wait till the clock move on
+	now := time.Now()
+	calls := 0
+	origNow := timeNow
+	t.Cleanup(func() { timeNow = origNow })
+	timeNow = func() time.Time {
+		calls++
+		if calls == 1 {
+			return now
+		}
+		return now.Add(time.Microsecond)
+	}
+	st = g.Snapshot()
+	st.LastTimestamp = getTimestamp()
+	st.ClockSeq = clockSeqMask
+	calls = 0
+	if err := g.Restore(st); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if _, err := g.NewV1(); err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+
+	s := m.Snapshot()
+	if s.Generated["v1"] != 12 || s.Generated["v6"] != 1 {
+		t.Errorf("Generated = %v, want v1:12 v6:1", s.Generated)
+	}
+	if s.ClockRegression != 1 {
+		t.Errorf("ClockRegression = %d, want 1", s.ClockRegression)
+	}
+	if s.ClockSeqOverflow != 1 {
+		t.Errorf("ClockSeqOverflow = %d, want 1", s.ClockSeqOverflow)
+	}
+	if s.WaitTime <= 0 {
+		t.Errorf("WaitTime = %s, want > 0", s.WaitTime)
+	}
+}
+
+// TestObserverV7 tests counter overflow & regression events of v7 generator
+func TestObserverV7(t *testing.T) {
+	now := time.Now()
+	withClockAndEntropy(t, now, make([]byte, 64))
+
+	// first read on the full millisecond, then the clock move on
+	calls := 0
+	timeNow = func() time.Time {
+		calls++
+		if calls == 1 {
+			return now
+		}
+		return now.Add(time.Millisecond)
+	}
+
+	m := &Metrics{}
+	g, _ := NewUUIDGeneratorV7()
+	g.Observer = m
+
+	g.Restore(UUIDv7State{LastMillis: now.UnixMilli(), Counter: 4095})
+	if _, err := g.NewV7(); err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+
+	g.Restore(UUIDv7State{LastMillis: now.UnixMilli() + 1000})
+	if _, err := g.NewV7(); err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+
+	s := m.Snapshot()
+	if s.Generated["v7"] != 2 {
+		t.Errorf("Generated = %v, want v7:2", s.Generated)
+	}
+	if s.CounterOverflow != 1 || s.ClockRegression != 1 {
+		t.Errorf("CounterOverflow = %d, ClockRegression = %d, want 1, 1", s.CounterOverflow, s.ClockRegression)
+	}
+	if s.WaitTime <= 0 {
+		t.Errorf("WaitTime = %s, want > 0", s.WaitTime)
+	}
+}
+
+// TestMetricsPublishExpvar tests expvar output & duplicate name
+func TestMetricsPublishExpvar(t *testing.T) {
+	m := &Metrics{}
+	m.Generated(4)
+	m.Waited(1, 3*time.Millisecond)
+
+	const name = "pgo_uuid_test_metrics"
+	if err := m.PublishExpvar(name); err != nil {
+		t.Fatalf("PublishExpvar() error = %v", err)
+	}
+	if err := m.PublishExpvar(name); err == nil {
+		t.Error("PublishExpvar() same name expected error")
+	}
+
+	var got MetricsSnapshot
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &got); err != nil {
+		t.Fatalf("expvar json error = %v", err)
+	}
+	if got.Generated["v4"] != 1 || got.WaitTime != 3*time.Millisecond {
+		t.Errorf("expvar = %+v", got)
+	}
+}
+
+// TestSetObserver tests attaching an observer to the global generators
+func TestSetObserver(t *testing.T) {
+	m := &Metrics{}
+	if err := SetObserver(m); err != nil {
+		t.Fatalf("SetObserver() error = %v", err)
+	}
+	t.Cleanup(func() { SetObserver(nil) })
+
+	UUIDv1asString()
+	UUIDv7asString()
+
+	s := m.Snapshot()
+	if s.Generated["v1"] != 1 || s.Generated["v7"] != 1 {
+		t.Errorf("Generated = %v, want v1:1 v7:1", s.Generated)
+	}
+}
@@ -0,0 +1,162 @@
+package pgo
+
+import (
+	"errors"
+	"io"
+)
+
+// --------------------------------------------------------- //
+
+// uuid found in text
+type UUIDMatch struct {
+	Offset  int    // byte offset of the first char of Text
+	End     int    // byte offset right after Text
+	Text    string // matched text, including braces or urn prefix
+	UUID    UUID
+	Version int
+}
+
+const (
+	scanUUIDLen = 36
+	scanURNLen  = len("urn:uuid:")
+
+	// bytes needed before & after the 36 char body to decide a match
+	scanBefore = scanURNLen + 1
+	scanAfter  = 1
+
+	scanChunk = 32 * 1024
+)
+
+// find every uuid in `s`
+//
+// recognize canonical (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx), braced
+// ({...}) & urn (urn:uuid:...) forms in a single pass, a match must not
+// be glued to other hex digits, 32 char compact hex is not reported since
+// it can't be told apart from hashes
+//
+// params:
+//
+//	s string - source
+//
+// return: []UUIDMatch - matches ordered by offset
+func UUIDscanString(s string) []UUIDMatch {
+	res, _ := scanUUIDs(s, 0, 0, true, nil)
+	return res
+}
+
+// find every uuid in `r`, see UUIDscanString
+//
+// the reader is consumed in chunks, a uuid spanning two chunks is found
+//
+// params:
+//
+//	r io.Reader - source
+//
+// return: []UUIDMatch, error - matches ordered by offset, err||nil
+func UUIDscanReader(r io.Reader) ([]UUIDMatch, error) {
+	var res []UUIDMatch
+
+	buf := make([]byte, 0, scanChunk)
+	base := 0 // offset of buf[0] in the stream
+	from := 0 // next position of buf to scan
+	for {
+		if cap(buf)-len(buf) < scanChunk/2 {
+			// keep only the context still needed by the next scan
+			keep := max(from-scanBefore, 0)
+			n := copy(buf, buf[keep:])
+			buf = buf[:n]
+			base += keep
+			from -= keep
+			if cap(buf)-len(buf) < scanChunk/2 {
+				buf = append(make([]byte, 0, 2*cap(buf)), buf...)
+			}
+		}
+
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+
+		final := errors.Is(err, io.EOF)
+		if err != nil && !final {
+			return res, err
+		}
+
+		res, from = scanUUIDs(buf, base, from, final, res)
+		if final {
+			return res, nil
+		}
+	}
+}
+
+// scan `s` from `from`, append found matches to `res` with offsets
+// shifted by `base`
+//
+// when not `final` stop before a position lacking the bytes needed to
+// decide, return the position to resume from once more data is available
+func scanUUIDs[T string | []byte](s T, base, from int, final bool, res []UUIDMatch) ([]UUIDMatch, int) {
+	i := from
+	for ; i+scanUUIDLen <= len(s); i++ {
+		if !final && i+scanUUIDLen+scanAfter > len(s) {
+			break
+		}
+		if xvalues[s[i]] == 255 || (i > 0 && xvalues[s[i-1]] != 255) {
+			continue
+		}
+
+		u, ok := scanCanonical(s[i : i+scanUUIDLen])
+		if !ok {
+			continue
+		}
+		end := i + scanUUIDLen
+		if end < len(s) && xvalues[s[end]] != 255 {
+			continue
+		}
+
+		start := i
+		switch {
+		case i > 0 && s[i-1] == '{' && end < len(s) && s[end] == '}':
+			start, end = i-1, end+1
+		case i >= scanURNLen && isURNprefix(s[i-scanURNLen:i]):
+			start = i - scanURNLen
+		}
+
+		res = append(res, UUIDMatch{
+			Offset:  base + start,
+			End:     base + end,
+			Text:    string(s[start:end]),
+			UUID:    u,
+			Version: u.Version(),
+		})
+		i = end - 1
+	}
+	return res, i
+}
+
+// parse 36 char canonical form
+func scanCanonical[T string | []byte](s T) (UUID, bool) {
+	var u UUID
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, false
+	}
+	for i, x := range [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34} {
+		var ok bool
+		if u[i], ok = xToByte(s[x], s[x+1]); !ok {
+			return u, false
+		}
+	}
+	return u, true
+}
+
+// case insensitive "urn:uuid:"
+func isURNprefix[T string | []byte](s T) bool {
+	const urn = "urn:uuid:"
+	for i := 0; i < len(urn); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != urn[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package pgo
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const scanSample = `2024-05-01 ERROR user=919108f7-52d1-4320-9bac-f847db4148a8 failed
+ticket {017F22E2-79B0-7CC3-98C4-DC0C0C07398F} ref URN:UUID:f81d4fae-7dec-11d0-a765-00a0c91e6bf6.
+hash 919108f752d143209bacf847db4148a8 glued a919108f7-52d1-4320-9bac-f847db4148a8 x
+trailing 1ec9414c-232a-6b00-b3c8-9f6bdeced846`
+
+var scanWant = []UUIDMatch{
+	{Offset: 22, End: 58, Text: "919108f7-52d1-4320-9bac-f847db4148a8", Version: 4},
+	{Offset: 73, End: 111, Text: "{017F22E2-79B0-7CC3-98C4-DC0C0C07398F}", Version: 7},
+	{Offset: 116, End: 161, Text: "URN:UUID:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", Version: 1},
+	{Offset: 256, End: 292, Text: "1ec9414c-232a-6b00-b3c8-9f6bdeced846", Version: 6},
+}
+
+// helper to compare matches against scanWant, UUID parsed from Text
+func checkScan(t *testing.T, got []UUIDMatch) {
+	t.Helper()
+	if len(got) != len(scanWant) {
+		t.Fatalf("found %d matches, want %d: %+v", len(got), len(scanWant), got)
+	}
+	for i, w := range scanWant {
+		w.UUID = mustUUID(t, w.Text)
+		if got[i] != w {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], w)
+		}
+		if scanSample[w.Offset:w.End] != w.Text {
+			t.Errorf("match %d offsets do not point to %q", i, w.Text)
+		}
+	}
+}
+
+// TestUUIDscanString tests every form, offsets & boundaries
+func TestUUIDscanString(t *testing.T) {
+	checkScan(t, UUIDscanString(scanSample))
+
+	for _, s := range []string{
+		"",
+		"919108f7-52d1-4320-9bac-f847db4148a",   // short
+		"919108f7-52d1-4320-9bac-f847db4148a8f", // glued right
+		"919108f7_52d1-4320-9bac-f847db4148a8",  // wrong separator
+		"919108f7-52d1-4320-9bac-f847db4148ag",  // not hex
+	} {
+		if got := UUIDscanString(s); len(got) != 0 {
+			t.Errorf("UUIDscanString(%q) = %+v, want none", s, got)
+		}
+	}
+}
+
+// TestUUIDscanReader tests uuid spanning read boundaries
+func TestUUIDscanReader(t *testing.T) {
+	got, err := UUIDscanReader(iotest.OneByteReader(strings.NewReader(scanSample)))
+	if err != nil {
+		t.Fatalf("UUIDscanReader() error = %v", err)
+	}
+	checkScan(t, got)
+
+	got, err = UUIDscanReader(iotest.DataErrReader(strings.NewReader(scanSample)))
+	if err != nil {
+		t.Fatalf("UUIDscanReader() error = %v", err)
+	}
+	checkScan(t, got)
+
+	if _, err := UUIDscanReader(iotest.ErrReader(io.ErrUnexpectedEOF)); err != io.ErrUnexpectedEOF {
+		t.Errorf("UUIDscanReader() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+// TestUUIDscanReaderLarge tests offsets past several chunks
+func TestUUIDscanReaderLarge(t *testing.T) {
+	const id = "919108f7-52d1-4320-9bac-f847db4148a8"
+	var sb strings.Builder
+	var offsets []int
+	for sb.Len() < 5*scanChunk {
+		sb.WriteString(strings.Repeat("x", 1000+len(offsets)%37))
+		offsets = append(offsets, sb.Len())
+		sb.WriteString(id)
+	}
+
+	got, err := UUIDscanReader(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("UUIDscanReader() error = %v", err)
+	}
+	if len(got) != len(offsets) {
+		t.Fatalf("found %d matches, want %d", len(got), len(offsets))
+	}
+	for i, m := range got {
+		if m.Offset != offsets[i] || m.Text != id {
+			t.Fatalf("match %d = %+v, want offset %d", i, m, offsets[i])
+		}
+	}
+}
+
+func BenchmarkUUIDscanString(b *testing.B) {
+	s := strings.Repeat(scanSample+"\n", 100)
+	b.SetBytes(int64(len(s)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UUIDscanString(s)
+	}
+}
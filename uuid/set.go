@@ -0,0 +1,443 @@
+package pgo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+// --------------------------------------------------------- //
+
+// open addressing hash table keyed by the 16-byte uuid
+//
+// an all zero key mark an empty slot, the nil uuid itself
+// is kept aside in `hasNil` & `nilVal`
+type table[V any] struct {
+	keys   []UUID
+	vals   []V
+	count  int
+	hasNil bool
+	nilVal V
+}
+
+const (
+	tableMinSize = 8
+)
+
+// splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hash both halves, since v1/v6/v7 high bytes are mostly timestamp
+func hashUUID(u UUID) uint64 {
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+	return mix64(hi ^ mix64(lo))
+}
+
+func (t *table[V]) len() int {
+	if t.hasNil {
+		return t.count + 1
+	}
+	return t.count
+}
+
+// find slot of `u`
+//
+// return: int, bool - slot index, true if found
+func (t *table[V]) find(u UUID) (int, bool) {
+	if len(t.keys) == 0 {
+		return -1, false
+	}
+	mask := len(t.keys) - 1
+	i := int(hashUUID(u)) & mask
+	for {
+		switch t.keys[i] {
+		case u:
+			return i, true
+		case UUID{}:
+			return i, false
+		}
+		i = (i + 1) & mask
+	}
+}
+
+func (t *table[V]) get(u UUID) (V, bool) {
+	if u == (UUID{}) {
+		return t.nilVal, t.hasNil
+	}
+	if i, ok := t.find(u); ok {
+		return t.vals[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// insert or replace `u`
+//
+// return: bool - true if `u` was not present
+func (t *table[V]) put(u UUID, v V) bool {
+	if u == (UUID{}) {
+		added := !t.hasNil
+		t.hasNil, t.nilVal = true, v
+		return added
+	}
+
+	// keep load factor under 3/4
+	if (t.count+1)*4 > len(t.keys)*3 {
+		t.grow()
+	}
+
+	i, ok := t.find(u)
+	t.vals[i] = v
+	if ok {
+		return false
+	}
+	t.keys[i] = u
+	t.count++
+	return true
+}
+
+// remove `u` with backward shift deletion (no tombstone)
+//
+// return: bool - true if `u` was present
+func (t *table[V]) remove(u UUID) bool {
+	if u == (UUID{}) {
+		removed := t.hasNil
+		var zero V
+		t.hasNil, t.nilVal = false, zero
+		return removed
+	}
+
+	i, ok := t.find(u)
+	if !ok {
+		return false
+	}
+
+	var zero V
+	mask := len(t.keys) - 1
+	for j := (i + 1) & mask; t.keys[j] != (UUID{}); j = (j + 1) & mask {
+		home := int(hashUUID(t.keys[j])) & mask
+		// move entry `j` into the hole unless its home lie cyclically in (i, j]
+		if (j > i && (home <= i || home > j)) || (j < i && home <= i && home > j) {
+			t.keys[i], t.vals[i] = t.keys[j], t.vals[j]
+			i = j
+		}
+	}
+	t.keys[i], t.vals[i] = UUID{}, zero
+	t.count--
+	return true
+}
+
+func (t *table[V]) grow() {
+	size := tableMinSize
+	if len(t.keys) > 0 {
+		size = len(t.keys) * 2
+	}
+	t.resize(size)
+}
+
+// make room for at least `n` non-nil entries
+func (t *table[V]) reserve(n int) {
+	size := tableMinSize
+	for n*4 > size*3 {
+		size *= 2
+	}
+	if size > len(t.keys) {
+		t.resize(size)
+	}
+}
+
+func (t *table[V]) resize(size int) {
+	oldKeys, oldVals := t.keys, t.vals
+	t.keys = make([]UUID, size)
+	t.vals = make([]V, size)
+
+	mask := size - 1
+	for i, k := range oldKeys {
+		if k == (UUID{}) {
+			continue
+		}
+		j := int(hashUUID(k)) & mask
+		for t.keys[j] != (UUID{}) {
+			j = (j + 1) & mask
+		}
+		t.keys[j], t.vals[j] = k, oldVals[i]
+	}
+}
+
+func (t *table[V]) all() iter.Seq2[UUID, V] {
+	return func(yield func(UUID, V) bool) {
+		if t.hasNil && !yield(UUID{}, t.nilVal) {
+			return
+		}
+		for i, k := range t.keys {
+			if k == (UUID{}) {
+				continue
+			}
+			if !yield(k, t.vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// --------------------------------------------------------- //
+
+// compact set of uuid
+//
+// store the 16-byte values in an open addressing hash table,
+// 21 to 43 byte per id depending on load, against ~80 byte
+// per id for map[string]struct{} keyed by the 36-char string
+//
+// note: zero value is ready to use, not safe for concurrent use
+type Set struct {
+	t table[struct{}]
+}
+
+// create set holding `ids`
+//
+// params:
+//
+//	ids ...UUID - initial members
+//
+// return: *Set
+func NewSet(ids ...UUID) *Set {
+	s := &Set{}
+	s.t.reserve(len(ids))
+	for _, u := range ids {
+		s.t.put(u, struct{}{})
+	}
+	return s
+}
+
+// add `u` to the set
+//
+// return: bool - true if `u` was not a member yet
+func (s *Set) Add(u UUID) bool {
+	return s.t.put(u, struct{}{})
+}
+
+// report whether `u` is a member
+func (s *Set) Has(u UUID) bool {
+	_, ok := s.t.get(u)
+	return ok
+}
+
+// remove `u` from the set
+//
+// return: bool - true if `u` was a member
+func (s *Set) Remove(u UUID) bool {
+	return s.t.remove(u)
+}
+
+// number of members
+func (s *Set) Len() int {
+	return s.t.len()
+}
+
+// iterate members in unspecified order
+//
+// note: don't add or remove while iterating
+func (s *Set) All() iter.Seq[UUID] {
+	return func(yield func(UUID) bool) {
+		for u := range s.t.all() {
+			if !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// members in byte order
+func (s *Set) Sorted() []UUID {
+	res := make([]UUID, 0, s.Len())
+	for u := range s.All() {
+		res = append(res, u)
+	}
+	slices.SortFunc(res, UUIDcompare)
+	return res
+}
+
+// new set holding members of `s` or `o`
+func (s *Set) Union(o *Set) *Set {
+	res := &Set{}
+	res.t.reserve(s.Len() + o.Len())
+	for u := range s.All() {
+		res.Add(u)
+	}
+	for u := range o.All() {
+		res.Add(u)
+	}
+	return res
+}
+
+// new set holding members of both `s` & `o`
+func (s *Set) Intersect(o *Set) *Set {
+	small, big := s, o
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	res := &Set{}
+	for u := range small.All() {
+		if big.Has(u) {
+			res.Add(u)
+		}
+	}
+	return res
+}
+
+// --------------------------------------------------------- //
+
+// binary set file layout (big endian):
+//
+//	[0:4]   magic "PGOS"
+//	[4]     format version
+//	[5:8]   reserved, zero
+//	[8:16]  member count
+//	[16:]   members, 16 byte each, in byte order
+var setFileMagic = [4]byte{'P', 'G', 'O', 'S'}
+
+const (
+	setFileVersion    = 1
+	setFileHeaderSize = 16
+)
+
+// write the set in the compact binary format, implements io.WriterTo
+//
+// params:
+//
+//	w io.Writer - destination
+//
+// return: int64, error - written bytes, err||nil
+func (s *Set) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+
+	var header [setFileHeaderSize]byte
+	copy(header[0:4], setFileMagic[:])
+	header[4] = setFileVersion
+	binary.BigEndian.PutUint64(header[8:16], uint64(s.Len()))
+
+	var n int64
+	written, err := bw.Write(header[:])
+	n += int64(written)
+	if err != nil {
+		return n, err
+	}
+
+	for _, u := range s.Sorted() {
+		written, err = bw.Write(u[:])
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// read members written by WriteTo into the set, implements io.ReaderFrom
+//
+// params:
+//
+//	r io.Reader - source
+//
+// return: int64, error - read bytes, err||nil
+func (s *Set) ReadFrom(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+
+	var n int64
+	var header [setFileHeaderSize]byte
+	read, err := io.ReadFull(br, header[:])
+	n += int64(read)
+	if err != nil {
+		return n, fmt.Errorf("fail to read set header: %w", err)
+	}
+	if !bytes.Equal(header[0:4], setFileMagic[:]) {
+		return n, fmt.Errorf("wrong set file magic: %q", header[0:4])
+	}
+	if header[4] != setFileVersion {
+		return n, fmt.Errorf("unsupported set file version: %d", header[4])
+	}
+
+	count := binary.BigEndian.Uint64(header[8:16])
+	s.t.reserve(s.t.count + int(min(count, 1<<20)))
+
+	var prev UUID
+	for i := uint64(0); i < count; i++ {
+		var u UUID
+		read, err = io.ReadFull(br, u[:])
+		n += int64(read)
+		if err != nil {
+			return n, fmt.Errorf("fail to read set member %d: %w", i, err)
+		}
+		if i > 0 && prev.Compare(u) >= 0 {
+			return n, fmt.Errorf("set member %d out of order", i)
+		}
+		s.Add(u)
+		prev = u
+	}
+
+	return n, nil
+}
+
+// --------------------------------------------------------- //
+
+// compact map keyed by uuid
+//
+// same open addressing layout as Set
+//
+// note: zero value is ready to use, not safe for concurrent use
+type Map[V any] struct {
+	t table[V]
+}
+
+// create map with room for `n` entries
+func NewMap[V any](n int) *Map[V] {
+	m := &Map[V]{}
+	m.t.reserve(n)
+	return m
+}
+
+// set value of `u`
+//
+// return: bool - true if `u` was not present
+func (m *Map[V]) Set(u UUID, v V) bool {
+	return m.t.put(u, v)
+}
+
+// get value of `u`
+//
+// return: V, bool - value, true if found
+func (m *Map[V]) Get(u UUID) (V, bool) {
+	return m.t.get(u)
+}
+
+// delete `u`
+//
+// return: bool - true if `u` was present
+func (m *Map[V]) Delete(u UUID) bool {
+	return m.t.remove(u)
+}
+
+// number of entries
+func (m *Map[V]) Len() int {
+	return m.t.len()
+}
+
+// iterate entries in unspecified order
+//
+// note: don't set or delete while iterating
+func (m *Map[V]) All() iter.Seq2[UUID, V] {
+	return m.t.all()
+}
@@ -0,0 +1,296 @@
+package pgo
+
+import (
+	"bytes"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// helper to build n distinct v4 uuid
+func genUUIDv4s(tb testing.TB, n int) []UUID {
+	tb.Helper()
+	ids := make([]UUID, 0, n)
+	for i := 0; i < n; i++ {
+		u, err := UUIDv4()
+		if err != nil {
+			tb.Fatalf("UUIDv4() error = %v", err)
+		}
+		ids = append(ids, u)
+	}
+	return ids
+}
+
+// TestSetAddHasRemove tests basic membership operations
+func TestSetAddHasRemove(t *testing.T) {
+	var s Set
+	ids := genUUIDv4s(t, 1000)
+	ids = append(ids, UUID{}) // nil uuid is a valid member
+
+	for _, u := range ids {
+		if !s.Add(u) {
+			t.Fatalf("Add(%x) = false for new member", u)
+		}
+	}
+	if s.Len() != len(ids) {
+		t.Fatalf("Len() = %d, want %d", s.Len(), len(ids))
+	}
+	for _, u := range ids {
+		if s.Add(u) {
+			t.Fatalf("Add(%x) = true for existing member", u)
+		}
+		if !s.Has(u) {
+			t.Fatalf("Has(%x) = false", u)
+		}
+	}
+
+	// remove every other member, the rest must still be found
+	for i, u := range ids {
+		if i%2 == 0 && !s.Remove(u) {
+			t.Fatalf("Remove(%x) = false", u)
+		}
+	}
+	for i, u := range ids {
+		if got := s.Has(u); got != (i%2 != 0) {
+			t.Fatalf("Has(%x) = %v after remove, want %v", u, got, i%2 != 0)
+		}
+	}
+	if s.Remove(ids[0]) {
+		t.Error("Remove() = true for removed member")
+	}
+	if want := len(ids) / 2; s.Len() != want {
+		t.Errorf("Len() = %d, want %d", s.Len(), want)
+	}
+}
+
+// TestSetAll tests iteration over members
+func TestSetAll(t *testing.T) {
+	ids := genUUIDv4s(t, 100)
+	s := NewSet(ids...)
+
+	var got []UUID
+	for u := range s.All() {
+		got = append(got, u)
+	}
+	slices.SortFunc(got, UUIDcompare)
+	slices.SortFunc(ids, UUIDcompare)
+	if !slices.Equal(got, ids) {
+		t.Error("All() did not yield every member once")
+	}
+	if !slices.Equal(s.Sorted(), ids) {
+		t.Error("Sorted() differ from sorted members")
+	}
+
+	// early break
+	n := 0
+	for range s.All() {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("All() yielded %d after break, want 3", n)
+	}
+}
+
+// TestSetUnionIntersect tests set algebra
+func TestSetUnionIntersect(t *testing.T) {
+	ids := genUUIDv4s(t, 30)
+	a := NewSet(ids[:20]...)
+	b := NewSet(ids[10:]...)
+
+	union := a.Union(b)
+	if union.Len() != 30 {
+		t.Errorf("Union().Len() = %d, want 30", union.Len())
+	}
+	for _, u := range ids {
+		if !union.Has(u) {
+			t.Errorf("Union() missing %x", u)
+		}
+	}
+
+	inter := a.Intersect(b)
+	if inter.Len() != 10 {
+		t.Errorf("Intersect().Len() = %d, want 10", inter.Len())
+	}
+	for i, u := range ids {
+		if got := inter.Has(u); got != (i >= 10 && i < 20) {
+			t.Errorf("Intersect().Has(#%d) = %v", i, got)
+		}
+	}
+
+	// operands untouched
+	if a.Len() != 20 || b.Len() != 20 {
+		t.Errorf("operands changed: a=%d b=%d", a.Len(), b.Len())
+	}
+}
+
+// TestSetWriteReadRoundTrip tests the binary file format
+func TestSetWriteReadRoundTrip(t *testing.T) {
+	ids := append(genUUIDv4s(t, 500), UUID{})
+	s := NewSet(ids...)
+
+	var buf bytes.Buffer
+	n, err := s.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if want := int64(setFileHeaderSize + 16*len(ids)); n != want || int64(buf.Len()) != want {
+		t.Fatalf("WriteTo() wrote %d (buffer %d), want %d", n, buf.Len(), want)
+	}
+
+	var got Set
+	if _, err := got.ReadFrom(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if !slices.Equal(got.Sorted(), s.Sorted()) {
+		t.Error("ReadFrom() members differ from written set")
+	}
+}
+
+// TestSetReadFromInvalid tests rejection of malformed input
+func TestSetReadFromInvalid(t *testing.T) {
+	var valid bytes.Buffer
+	if _, err := NewSet(genUUIDv4s(t, 3)...).WriteTo(&valid); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	good := valid.Bytes()
+
+	badMagic := slices.Clone(good)
+	badMagic[0] = 'X'
+
+	badVersion := slices.Clone(good)
+	badVersion[4] = 99
+
+	unordered := slices.Clone(good)
+	copy(unordered[16:32], good[32:48])
+	copy(unordered[32:48], good[16:32])
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"empty", nil},
+		{"short header", good[:10]},
+		{"bad magic", badMagic},
+		{"bad version", badVersion},
+		{"truncated member", good[:len(good)-1]},
+		{"unordered members", unordered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Set
+			if _, err := s.ReadFrom(bytes.NewReader(tt.input)); err == nil {
+				t.Error("ReadFrom() expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestMap tests the uuid keyed map
+func TestMap(t *testing.T) {
+	ids := append(genUUIDv4s(t, 200), UUID{})
+	m := NewMap[int](len(ids))
+
+	for i, u := range ids {
+		if !m.Set(u, i) {
+			t.Fatalf("Set(#%d) = false for new key", i)
+		}
+	}
+	if m.Set(ids[0], -1) {
+		t.Error("Set() = true for existing key")
+	}
+	if v, ok := m.Get(ids[0]); !ok || v != -1 {
+		t.Errorf("Get() = %d, %v, want -1, true", v, ok)
+	}
+
+	for i, u := range ids[1:] {
+		if v, ok := m.Get(u); !ok || v != i+1 {
+			t.Fatalf("Get(#%d) = %d, %v", i+1, v, ok)
+		}
+	}
+
+	if !m.Delete(ids[1]) || m.Delete(ids[1]) {
+		t.Error("Delete() should report presence once")
+	}
+	if _, ok := m.Get(ids[1]); ok {
+		t.Error("Get() found deleted key")
+	}
+
+	count := 0
+	for u, v := range m.All() {
+		if got, _ := m.Get(u); got != v {
+			t.Errorf("All() yielded %d for key mapped to %d", v, got)
+		}
+		count++
+	}
+	if count != m.Len() || count != len(ids)-1 {
+		t.Errorf("All() yielded %d, Len() = %d, want %d", count, m.Len(), len(ids)-1)
+	}
+}
+
+// helper to measure retained heap of `build`
+func retainedHeap(build func() any) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	keep := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(keep)
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+const benchSetSize = 100_000
+
+func BenchmarkSetMemory(b *testing.B) {
+	ids := genUUIDv4s(b, benchSetSize)
+
+	b.ResetTimer()
+	var heap uint64
+	for i := 0; i < b.N; i++ {
+		heap = retainedHeap(func() any {
+			var s Set
+			for _, u := range ids {
+				s.Add(u)
+			}
+			return &s
+		})
+	}
+	b.ReportMetric(float64(heap)/benchSetSize, "B/id")
+}
+
+func BenchmarkMapOfStringsMemory(b *testing.B) {
+	strs := make([]string, benchSetSize)
+	for i := range strs {
+		strs[i], _ = UUIDv4asString()
+	}
+
+	b.ResetTimer()
+	var heap uint64
+	for i := 0; i < b.N; i++ {
+		heap = retainedHeap(func() any {
+			m := make(map[string]struct{})
+			for _, s := range strs {
+				// copy so the key own its backing array like a decoded id would
+				m[string([]byte(s))] = struct{}{}
+			}
+			return m
+		})
+	}
+	b.ReportMetric(float64(heap)/benchSetSize, "B/id")
+}
+
+func BenchmarkSetHas(b *testing.B) {
+	ids := genUUIDv4s(b, benchSetSize)
+	s := NewSet(ids...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !s.Has(ids[i%len(ids)]) {
+			b.Fatal("Has() = false")
+		}
+	}
+}
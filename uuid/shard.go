@@ -0,0 +1,76 @@
+package pgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// --------------------------------------------------------- //
+
+// route `u` to one of `shards` buckets with jump consistent hash
+//
+// growing from n to n+1 shards move only ~1/(n+1) of the ids, all of
+// them to the new shard (Lamping & Veach, 2014)
+//
+// params:
+//
+//	u UUID - id to route
+//	shards int - number of shards, at least 1
+//
+// return: int, error - shard index in [0, shards), err||nil
+func UUIDjumpShard(u UUID, shards int) (int, error) {
+	if shards < 1 {
+		return 0, fmt.Errorf("shard count must be at least 1, got %d", shards)
+	}
+
+	key := shardKey(u)
+	b, j := int64(-1), int64(0)
+	for j < int64(shards) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b), nil
+}
+
+// route `u` to one of the named `shards` with rendezvous (highest
+// random weight) hash
+//
+// unlike UUIDjumpShard any shard can be removed, only its ids move,
+// spread over the remaining shards
+//
+// params:
+//
+//	u UUID - id to route
+//	shards []string - distinct shard names, at least 1
+//
+// return: int, error - index of the chosen name in `shards`, err||nil
+func UUIDrendezvousShard(u UUID, shards []string) (int, error) {
+	if len(shards) == 0 {
+		return 0, fmt.Errorf("shard list is empty")
+	}
+
+	key := shardKey(u)
+	best, bestScore := 0, uint64(0)
+	for i, name := range shards {
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		if score := mix64(key ^ mix64(h.Sum64())); i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, nil
+}
+
+// get well distributed routing key of `u`
+//
+// v4 & v7 use their 62 random bits only (rand_b), so the key of a v7 does
+// not depend on its timestamp or counter, other versions hash all bytes
+func shardKey(u UUID) uint64 {
+	switch u.Version() {
+	case 4, 7:
+		return mix64(binary.BigEndian.Uint64(u[8:16]) & mask62)
+	}
+	return hashUUID(u)
+}
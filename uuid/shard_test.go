@@ -0,0 +1,179 @@
+package pgo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+// helper to generate `n` uuid of `version`
+//
+// entropy, clock & node are fixed, so the same ids (and chi-square)
+// come out on every run instead of failing 1 in 1000 per check
+func genShardUUIDs(t *testing.T, version, n int) []UUID {
+	t.Helper()
+	origNow, origRand := timeNow, randReader
+	t.Cleanup(func() { timeNow, randReader = origNow, origRand })
+
+	var seed [32]byte
+	seed[0] = byte(version)
+	randReader = rand.NewChaCha8(seed)
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		now = now.Add(time.Microsecond)
+		return now
+	}
+
+	g1, _ := NewUUIDv1Generator()
+	g1.Node = rfcNode
+	g7, _ := NewUUIDGeneratorV7()
+
+	res := make([]UUID, 0, n)
+	for i := 0; i < n; i++ {
+		var s string
+		var err error
+		switch version {
+		case 1:
+			s, err = g1.NewV1()
+		case 4:
+			s, err = UUIDv4asString()
+		case 5:
+			res = append(res, UUIDv5(NamespaceDNS, fmt.Sprintf("host-%d.example.com", i)))
+			continue
+		case 6:
+			s, err = g1.NewV6()
+		case 7:
+			s, err = g7.NewV7()
+		}
+		if err != nil {
+			t.Fatalf("generate v%d error = %v", version, err)
+		}
+		res = append(res, mustUUID(t, s))
+	}
+	return res
+}
+
+// helper chi-square of shard counts against uniform
+func chiSquare(counts []int, total int) float64 {
+	expected := float64(total) / float64(len(counts))
+	var chi2 float64
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi2 += d * d / expected
+	}
+	return chi2
+}
+
+// TestShardDistribution tests both routers spread every version uniformly
+func TestShardDistribution(t *testing.T) {
+	const total = 20_000
+	const shards = 16
+	names := make([]string, shards)
+	for i := range names {
+		names[i] = fmt.Sprintf("db-%02d", i)
+	}
+
+	for _, version := range []int{1, 4, 5, 6, 7} {
+		ids := genShardUUIDs(t, version, total)
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			jump := make([]int, shards)
+			rendezvous := make([]int, shards)
+			for _, u := range ids {
+				j, err := UUIDjumpShard(u, shards)
+				if err != nil {
+					t.Fatalf("UUIDjumpShard() error = %v", err)
+				}
+				r, err := UUIDrendezvousShard(u, names)
+				if err != nil {
+					t.Fatalf("UUIDrendezvousShard() error = %v", err)
+				}
+				jump[j]++
+				rendezvous[r]++
+			}
+
+			// chi-square with 15 degrees of freedom, 37.70 is the 0.999 quantile
+			if chi2 := chiSquare(jump, total); chi2 > 37.70 {
+				t.Errorf("jump distribution not uniform, chi2 = %.2f, counts = %v", chi2, jump)
+			}
+			if chi2 := chiSquare(rendezvous, total); chi2 > 37.70 {
+				t.Errorf("rendezvous distribution not uniform, chi2 = %.2f, counts = %v", chi2, rendezvous)
+			}
+		})
+	}
+}
+
+// TestUUIDjumpShardGrow tests ids only move to the added shard
+func TestUUIDjumpShardGrow(t *testing.T) {
+	ids := genShardUUIDs(t, 7, 10_000)
+
+	for n := 1; n < 20; n++ {
+		moved := 0
+		for _, u := range ids {
+			before, _ := UUIDjumpShard(u, n)
+			after, _ := UUIDjumpShard(u, n+1)
+			if before != after {
+				if after != n {
+					t.Fatalf("%s moved from %d to %d growing to %d shards", u, before, after, n+1)
+				}
+				moved++
+			}
+		}
+		// expect 1/(n+1) of the ids to move, allow 20% slack
+		want := float64(len(ids)) / float64(n+1)
+		if f := float64(moved); f < 0.8*want || f > 1.2*want {
+			t.Errorf("growing to %d shards moved %d ids, want ~%.0f", n+1, moved, want)
+		}
+	}
+}
+
+// TestUUIDrendezvousShardRemove tests only ids of the removed shard move
+func TestUUIDrendezvousShardRemove(t *testing.T) {
+	ids := genShardUUIDs(t, 4, 10_000)
+	names := []string{"a", "b", "c", "d", "e"}
+	rest := []string{"a", "b", "d", "e"} // without "c"
+
+	for _, u := range ids {
+		before, _ := UUIDrendezvousShard(u, names)
+		after, _ := UUIDrendezvousShard(u, rest)
+		if names[before] != "c" && names[before] != rest[after] {
+			t.Fatalf("%s moved from %s to %s", u, names[before], rest[after])
+		}
+	}
+}
+
+// TestShardKeyV7 tests v7 routing ignore timestamp & counter
+func TestShardKeyV7(t *testing.T) {
+	a := mustUUID(t, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	b := mustUUID(t, "0190a1b2-c3d4-7001-98c4-dc0c0c07398f") // same rand_b
+	if shardKey(a) != shardKey(b) {
+		t.Error("v7 shard key depend on timestamp or counter")
+	}
+
+	// v1 has no random part, all bytes count
+	c := mustUUID(t, "c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	d := mustUUID(t, "c232ab01-9414-11ec-b3c8-9f6bdeced846")
+	if shardKey(c) == shardKey(d) {
+		t.Error("v1 shard key ignore timestamp")
+	}
+}
+
+// TestShardInvalid tests argument validation
+func TestShardInvalid(t *testing.T) {
+	if _, err := UUIDjumpShard(NamespaceDNS, 0); err == nil {
+		t.Error("UUIDjumpShard(0) expected error")
+	}
+	if _, err := UUIDrendezvousShard(NamespaceDNS, nil); err == nil {
+		t.Error("UUIDrendezvousShard(nil) expected error")
+	}
+	if s, _ := UUIDjumpShard(NamespaceDNS, 1); s != 0 {
+		t.Errorf("UUIDjumpShard(1) = %d, want 0", s)
+	}
+}
+
+func BenchmarkUUIDjumpShard(b *testing.B) {
+	u, _ := UUIDfromString("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	for i := 0; i < b.N; i++ {
+		UUIDjumpShard(u, 1024)
+	}
+}
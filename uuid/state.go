@@ -0,0 +1,97 @@
+package pgo
+
+import (
+	"fmt"
+)
+
+// --------------------------------------------------------- //
+
+// serializable state of UUIDv1Generator
+//
+// save it before a hot restart & restore it in the new process,
+// so the new generator continue after the last issued timestamp
+type UUIDv1State struct {
+	LastTimestamp uint64  `json:"last_timestamp"` // 60-bit gregorian, 100ns unit
+	ClockSeq      uint16  `json:"clock_seq"`      // 14-bit
+	Node          [6]byte `json:"node"`
+}
+
+// serializable state of UUIDGeneratorV7
+type UUIDv7State struct {
+	LastMillis int64  `json:"last_millis"` // 48-bit unix milliseconds
+	Counter    uint16 `json:"counter"`     // 12-bit
+}
+
+// get a consistent copy of the generator state
+//
+// return: UUIDv1State
+func (g *UUIDv1Generator) Snapshot() UUIDv1State {
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	return UUIDv1State{
+		LastTimestamp: g.LastTimestamp,
+		ClockSeq:      g.ClockSeq,
+		Node:          g.Node,
+	}
+}
+
+// replace the generator state with `s`
+//
+// params:
+//
+//	s UUIDv1State - state from Snapshot
+//
+// return: error - err||nil
+func (g *UUIDv1Generator) Restore(s UUIDv1State) error {
+	if s.LastTimestamp >= 1<<60 {
+		return fmt.Errorf("uuid v1 state timestamp %d overflow 60 bit", s.LastTimestamp)
+	}
+	if s.ClockSeq > clockSeqMask {
+		return fmt.Errorf("uuid v1 state clock seq %d overflow 14 bit", s.ClockSeq)
+	}
+
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	g.LastTimestamp = s.LastTimestamp
+	g.ClockSeq = s.ClockSeq
+	g.Node = s.Node
+	return nil
+}
+
+// get a consistent copy of the generator state
+//
+// return: UUIDv7State
+func (g *UUIDGeneratorV7) Snapshot() UUIDv7State {
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	return UUIDv7State{
+		LastMillis: g.LastMillis,
+		Counter:    g.Counter,
+	}
+}
+
+// replace the generator state with `s`
+//
+// params:
+//
+//	s UUIDv7State - state from Snapshot
+//
+// return: error - err||nil
+func (g *UUIDGeneratorV7) Restore(s UUIDv7State) error {
+	if s.LastMillis < 0 || s.LastMillis >= 1<<48 {
+		return fmt.Errorf("uuid v7 state millis %d out of 48 bit range", s.LastMillis)
+	}
+	if s.Counter > 4095 {
+		return fmt.Errorf("uuid v7 state counter %d overflow 12 bit", s.Counter)
+	}
+
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	g.LastMillis = s.LastMillis
+	g.Counter = s.Counter
+	return nil
+}
@@ -0,0 +1,122 @@
+package pgo
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestUUIDv1StateRoundTrip tests handing state to a new generator via json
+func TestUUIDv1StateRoundTrip(t *testing.T) {
+	old, _ := NewUUIDv1Generator()
+	// last timestamp ahead of the clock, as if the old process issued it
+	ahead := old.Snapshot()
+	ahead.LastTimestamp = uint64(time.Now().Add(time.Hour).UnixNano()/100) + gregorianOffset
+	if err := old.Restore(ahead); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	last, _ := old.NewV1()
+
+	data, err := json.Marshal(old.Snapshot())
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var s UUIDv1State
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if s != old.Snapshot() {
+		t.Fatalf("state json round trip = %+v, want %+v", s, old.Snapshot())
+	}
+
+	g := &UUIDv1Generator{}
+	if err := g.Restore(s); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	next, _ := g.NewV1()
+
+	// clock is behind the restored timestamp, the clock seq must move on
+	lastU, _ := UUIDfromString(last)
+	nextU, _ := UUIDfromString(next)
+	if lastU == nextU || timeOrderSeq(lastU) == timeOrderSeq(nextU) {
+		t.Errorf("restored generator repeat state: %s after %s", next, last)
+	}
+	if g.Snapshot().Node != s.Node {
+		t.Error("Restore() did not keep the node")
+	}
+}
+
+// TestUUIDv7StateRoundTrip tests the v7 counter continue after restore
+func TestUUIDv7StateRoundTrip(t *testing.T) {
+	now := time.Now()
+	withClockAndEntropy(t, now, make([]byte, 64))
+
+	old, _ := NewUUIDGeneratorV7()
+	for i := 0; i < 10; i++ {
+		old.NewV7()
+	}
+	s := old.Snapshot()
+	if s.LastMillis != now.UnixMilli() || s.Counter != 10 {
+		t.Fatalf("Snapshot() = %+v, want {%d 10}", s, now.UnixMilli())
+	}
+
+	g, _ := NewUUIDGeneratorV7()
+	if err := g.Restore(s); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	g.NewV7()
+	if got := g.Snapshot().Counter; got != 11 {
+		t.Errorf("Counter after restore = %d, want 11", got)
+	}
+}
+
+// TestStateRestoreInvalid tests out of range state rejection
+func TestStateRestoreInvalid(t *testing.T) {
+	g1 := &UUIDv1Generator{}
+	for _, s := range []UUIDv1State{
+		{LastTimestamp: 1 << 60},
+		{ClockSeq: clockSeqMask + 1},
+	} {
+		if err := g1.Restore(s); err == nil {
+			t.Errorf("UUIDv1Generator.Restore(%+v) expected error", s)
+		}
+	}
+
+	g7 := &UUIDGeneratorV7{}
+	for _, s := range []UUIDv7State{
+		{LastMillis: -1},
+		{LastMillis: 1 << 48},
+		{Counter: 4096},
+	} {
+		if err := g7.Restore(s); err == nil {
+			t.Errorf("UUIDGeneratorV7.Restore(%+v) expected error", s)
+		}
+	}
+}
+
+// TestStateConcurrent tests snapshot & restore while generating (run with -race)
+func TestStateConcurrent(t *testing.T) {
+	g1, _ := NewUUIDv1Generator()
+	g7, _ := NewUUIDGeneratorV7()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				g1.NewV1()
+				g7.NewV7()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				g1.Restore(g1.Snapshot())
+				g7.Restore(g7.Snapshot())
+			}
+		}()
+	}
+	wg.Wait()
+}
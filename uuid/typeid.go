@@ -0,0 +1,217 @@
+package pgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------- //
+
+// TypeID suffix alphabet, lowercase crockford base32
+const typeIDalphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// crockford check symbols for values 32-36, lowercase to match the alphabet
+const typeIDcheckSymbols = "*~$=u"
+
+const (
+	typeIDsuffixLen    = 26
+	typeIDmaxPrefixLen = 63
+)
+
+// lookup table of typeIDalphabet, 0xFF if invalid
+var typeIDvalues = func() [256]byte {
+	var res [256]byte
+	for i := range res {
+		res[i] = 0xFF
+	}
+	for i := 0; i < len(typeIDalphabet); i++ {
+		res[typeIDalphabet[i]] = byte(i)
+	}
+	return res
+}()
+
+// encode `u` as TypeID: "prefix_" + 26 char base32 of the 16 bytes
+//
+// params:
+//
+//	prefix string - lowercase a-z & '_', up to 63 char, empty for no prefix
+//	u UUID - source
+//
+// return: string, error - typeid, err||nil
+func UUIDtoTypeID(prefix string, u UUID) (string, error) {
+	if err := validateTypeIDprefix(prefix); err != nil {
+		return "", err
+	}
+	return joinTypeID(prefix, encodeTypeIDsuffix(u)), nil
+}
+
+// encode `u` as TypeID followed by one crockford check character
+//
+// the check character (mod 37) detect any single character error
+// & any transposition of two adjacent characters in the suffix
+//
+// note: the result is 27 char long after the prefix, so plain TypeID
+// parsers will reject it, use UUIDfromTypeIDchecked
+//
+// params:
+//
+//	prefix string - lowercase a-z & '_', up to 63 char, empty for no prefix
+//	u UUID - source
+//
+// return: string, error - typeid with check character, err||nil
+func UUIDtoTypeIDchecked(prefix string, u UUID) (string, error) {
+	if err := validateTypeIDprefix(prefix); err != nil {
+		return "", err
+	}
+	return joinTypeID(prefix, encodeTypeIDsuffix(u)+string(typeIDcheckSymbol(u))), nil
+}
+
+// parse TypeID `s` and check its prefix equal `prefix`
+//
+// params:
+//
+//	s string - source
+//	prefix string - expected prefix, empty for no prefix
+//
+// return: UUID, error - uuid, err||nil
+func UUIDfromTypeID(s, prefix string) (UUID, error) {
+	suffix, err := cutTypeIDprefix(s, prefix)
+	if err != nil {
+		return UUID{}, err
+	}
+	return decodeTypeIDsuffix(suffix)
+}
+
+// parse TypeID `s` ending with a check character, see UUIDtoTypeIDchecked
+//
+// params:
+//
+//	s string - source
+//	prefix string - expected prefix, empty for no prefix
+//
+// return: UUID, error - uuid, err||nil
+func UUIDfromTypeIDchecked(s, prefix string) (UUID, error) {
+	suffix, err := cutTypeIDprefix(s, prefix)
+	if err != nil {
+		return UUID{}, err
+	}
+	if len(suffix) != typeIDsuffixLen+1 {
+		return UUID{}, fmt.Errorf("wrong typeid suffix length: %d, want %d", len(suffix), typeIDsuffixLen+1)
+	}
+
+	u, err := decodeTypeIDsuffix(suffix[:typeIDsuffixLen])
+	if err != nil {
+		return UUID{}, err
+	}
+	if check := suffix[typeIDsuffixLen]; check != typeIDcheckSymbol(u) {
+		return UUID{}, fmt.Errorf("typeid check character mismatch: %q", check)
+	}
+	return u, nil
+}
+
+// --------------------------------------------------------- //
+
+func validateTypeIDprefix(prefix string) error {
+	if len(prefix) > typeIDmaxPrefixLen {
+		return fmt.Errorf("typeid prefix too long: %d char, max %d", len(prefix), typeIDmaxPrefixLen)
+	}
+	if prefix == "" {
+		return nil
+	}
+	if prefix[0] == '_' || prefix[len(prefix)-1] == '_' {
+		return fmt.Errorf("typeid prefix cannot start or end with '_': %q", prefix)
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return fmt.Errorf("wrong typeid prefix character %q at %d", c, i)
+		}
+	}
+	return nil
+}
+
+func joinTypeID(prefix, suffix string) string {
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "_" + suffix
+}
+
+// split `s` at its last '_' and check the prefix part
+func cutTypeIDprefix(s, prefix string) (string, error) {
+	got, suffix := "", s
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		got, suffix = s[:i], s[i+1:]
+		if got == "" {
+			return "", fmt.Errorf("typeid with empty prefix cannot have '_' separator")
+		}
+	}
+	if err := validateTypeIDprefix(got); err != nil {
+		return "", err
+	}
+	if got != prefix {
+		return "", fmt.Errorf("wrong typeid prefix: %q, want %q", got, prefix)
+	}
+	return suffix, nil
+}
+
+// encode the 128 bit as 26 char, the first char carry 2 zero padding bits
+func encodeTypeIDsuffix(u UUID) string {
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+
+	var buf [typeIDsuffixLen]byte
+	for i := range buf {
+		buf[i] = typeIDalphabet[bits5(hi, lo, uint((typeIDsuffixLen-1-i)*5))]
+	}
+	return string(buf[:])
+}
+
+func decodeTypeIDsuffix(s string) (UUID, error) {
+	if len(s) != typeIDsuffixLen {
+		return UUID{}, fmt.Errorf("wrong typeid suffix length: %d, want %d", len(s), typeIDsuffixLen)
+	}
+	// first char hold 3 bit only, anything above '7' overflow 128 bit
+	if s[0] > '7' {
+		return UUID{}, fmt.Errorf("typeid suffix overflow 128 bit: %q", s[0])
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := typeIDvalues[s[i]]
+		if v == 0xFF {
+			return UUID{}, fmt.Errorf("wrong typeid suffix character %q at %d", s[i], i)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], hi)
+	binary.BigEndian.PutUint64(u[8:16], lo)
+	return u, nil
+}
+
+// get 5 bit at `shift` of the 128 bit number hi:lo
+func bits5(hi, lo uint64, shift uint) byte {
+	switch {
+	case shift >= 64:
+		return byte(hi>>(shift-64)) & 31
+	case shift+5 <= 64:
+		return byte(lo>>shift) & 31
+	default:
+		return byte(lo>>shift|hi<<(64-shift)) & 31
+	}
+}
+
+// crockford check symbol of `u` read as 128 bit number, mod 37
+func typeIDcheckSymbol(u UUID) byte {
+	var r uint32
+	for _, b := range u {
+		r = (r<<8 | uint32(b)) % 37
+	}
+	if r < 32 {
+		return typeIDalphabet[r]
+	}
+	return typeIDcheckSymbols[r-32]
+}
@@ -0,0 +1,158 @@
+package pgo
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTypeIDVectors tests the TypeID spec valid vectors
+func TestTypeIDVectors(t *testing.T) {
+	tests := []struct {
+		name   string
+		typeid string
+		prefix string
+		uuid   string
+	}{
+		{"nil", "00000000000000000000000000", "", "00000000-0000-0000-0000-000000000000"},
+		{"one", "00000000000000000000000001", "", "00000000-0000-0000-0000-000000000001"},
+		{"ten", "0000000000000000000000000a", "", "00000000-0000-0000-0000-00000000000a"},
+		{"sixteen", "0000000000000000000000000g", "", "00000000-0000-0000-0000-000000000010"},
+		{"thirty-two", "00000000000000000000000010", "", "00000000-0000-0000-0000-000000000020"},
+		{"max-valid", "7zzzzzzzzzzzzzzzzzzzzzzzzz", "", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"valid-alphabet", "prefix_0123456789abcdefghjkmnpqrs", "prefix", "0110c853-1d09-52d8-d73e-1194e95b5f19"},
+		{"valid-uuidv7", "prefix_01h455vb4pex5vsknk084sn02q", "prefix", "01890a5d-ac96-774b-bcce-b302099a8057"},
+		{"prefix-underscore", "pre_fix_00000000000000000000000000", "pre_fix", "00000000-0000-0000-0000-000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UUIDfromString(tt.uuid)
+			if err != nil {
+				t.Fatalf("UUIDfromString() error = %v", err)
+			}
+
+			got, err := UUIDtoTypeID(tt.prefix, u)
+			if err != nil {
+				t.Fatalf("UUIDtoTypeID() error = %v", err)
+			}
+			if got != tt.typeid {
+				t.Errorf("UUIDtoTypeID() = %q, want %q", got, tt.typeid)
+			}
+
+			back, err := UUIDfromTypeID(tt.typeid, tt.prefix)
+			if err != nil {
+				t.Fatalf("UUIDfromTypeID() error = %v", err)
+			}
+			if back != u {
+				t.Errorf("UUIDfromTypeID() = %s, want %s", back, u)
+			}
+		})
+	}
+}
+
+// TestTypeIDInvalid tests the strict parser rejections
+func TestTypeIDInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		typeid string
+		prefix string
+	}{
+		{"prefix-uppercase", "PREFIX_00000000000000000000000000", "PREFIX"},
+		{"prefix-numeric", "12345_00000000000000000000000000", "12345"},
+		{"prefix-period", "pre.fix_00000000000000000000000000", "pre.fix"},
+		{"prefix-leading-underscore", "_prefix_00000000000000000000000000", "_prefix"},
+		{"prefix-trailing-underscore", "prefix__00000000000000000000000000", "prefix_"},
+		{"prefix-too-long", strings.Repeat("a", 64) + "_00000000000000000000000000", strings.Repeat("a", 64)},
+		{"wrong-prefix", "user_00000000000000000000000000", "order"},
+		{"unexpected-prefix", "user_00000000000000000000000000", ""},
+		{"missing-prefix", "00000000000000000000000000", "user"},
+		{"separator-empty-prefix", "_00000000000000000000000000", ""},
+		{"empty", "", ""},
+		{"suffix-short", "prefix_1234567890123456789012345", "prefix"},
+		{"suffix-long", "prefix_123456789012345678901234567", "prefix"},
+		{"suffix-uppercase", "prefix_00000000000000000000000000A", "prefix"},
+		{"suffix-ambiguous", "prefix_0000000000000000000000000i", "prefix"},
+		{"suffix-hyphen", "prefix_0000000000000000000000000-", "prefix"},
+		{"suffix-overflow", "8zzzzzzzzzzzzzzzzzzzzzzzzz", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if u, err := UUIDfromTypeID(tt.typeid, tt.prefix); err == nil {
+				t.Errorf("UUIDfromTypeID(%q) = %s, expected error", tt.typeid, u)
+			}
+		})
+	}
+
+	if _, err := UUIDtoTypeID("Bad", UUID{}); err == nil {
+		t.Error("UUIDtoTypeID() expected prefix error")
+	}
+}
+
+// TestTypeIDChecked tests the check character round trip & error detection
+func TestTypeIDChecked(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		u, _ := UUIDv7()
+
+		s, err := UUIDtoTypeIDchecked("tkt", u)
+		if err != nil {
+			t.Fatalf("UUIDtoTypeIDchecked() error = %v", err)
+		}
+		back, err := UUIDfromTypeIDchecked(s, "tkt")
+		if err != nil {
+			t.Fatalf("UUIDfromTypeIDchecked(%q) error = %v", s, err)
+		}
+		if back != u {
+			t.Fatalf("UUIDfromTypeIDchecked() = %s, want %s", back, u)
+		}
+
+		// unchecked parser reject the extra character
+		if _, err := UUIDfromTypeID(s, "tkt"); err == nil {
+			t.Fatalf("UUIDfromTypeID(%q) accepted check character", s)
+		}
+	}
+}
+
+// TestTypeIDCheckedDetectErrors tests every single substitution & adjacent transposition is caught
+func TestTypeIDCheckedDetectErrors(t *testing.T) {
+	u, _ := UUIDfromString("01890a5d-ac96-774b-bcce-b302099a8057")
+	s, _ := UUIDtoTypeIDchecked("", u)
+	suffix := s[:typeIDsuffixLen]
+	check := s[typeIDsuffixLen:]
+
+	// single character substitution
+	for i := 0; i < len(suffix); i++ {
+		for _, c := range []byte(typeIDalphabet) {
+			if c == suffix[i] {
+				continue
+			}
+			typo := suffix[:i] + string(c) + suffix[i+1:] + check
+			if _, err := UUIDfromTypeIDchecked(typo, ""); err == nil {
+				t.Fatalf("substitution at %d (%q) not detected", i, typo)
+			}
+		}
+	}
+
+	// adjacent transposition
+	for i := 0; i+1 < len(suffix); i++ {
+		if suffix[i] == suffix[i+1] {
+			continue
+		}
+		b := []byte(suffix)
+		b[i], b[i+1] = b[i+1], b[i]
+		typo := string(b) + check
+		if _, err := UUIDfromTypeIDchecked(typo, ""); err == nil {
+			t.Fatalf("transposition at %d (%q) not detected", i, typo)
+		}
+	}
+
+	// wrong check character
+	for _, c := range []byte(typeIDalphabet + typeIDcheckSymbols) {
+		if string(c) == check {
+			continue
+		}
+		if _, err := UUIDfromTypeIDchecked(suffix+string(c), ""); err == nil {
+			t.Fatalf("check character %q accepted, want %q", c, check)
+		}
+	}
+}
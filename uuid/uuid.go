@@ -2,10 +2,16 @@ package pgo
 
 import (
 	"bytes"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha1"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"io"
 	"net"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +22,26 @@ import (
 // 128 bit (16 byte) uuid as defined in RFC 4122
 type UUID [16]byte
 
+// get canonical form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// get uuid version, the high nibble of the 7th byte [6]
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
 // --------------------------------------------------------- //
 
 type UUIDv1Generator struct {
@@ -23,6 +49,22 @@ type UUIDv1Generator struct {
 	LastTimestamp uint64
 	ClockSeq      uint16
 	Node          [6]byte
+	Observer      Observer // optional, nil to disable
+
+	// v2 clock seq per domain & id, only of the current time_mid window
+	v2Window uint64
+	v2Seq    map[v2Key]*v2Slot
+}
+
+type v2Key struct {
+	domain Domain
+	id     uint32
+}
+
+// 6-bit clock seq of one domain & id, `used` of 64 already issued
+type v2Slot struct {
+	seq  uint8
+	used uint8
 }
 
 const (
@@ -36,6 +78,19 @@ var (
 	GlobalGeneratorV1Err  error
 )
 
+// entropy & clock source of every generator,
+// swapped by tests to replay known vectors
+var (
+	randReader io.Reader = rand.Reader
+	timeNow              = time.Now
+)
+
+// fill `b` from randReader
+func readRandom(b []byte) error {
+	_, err := io.ReadFull(randReader, b)
+	return err
+}
+
 func GetNodeID() ([6]byte, error) {
 	// strat:
 	// try get address from non-loopback interface
@@ -60,7 +115,7 @@ func GetNodeID() ([6]byte, error) {
 
 	// fallback random multicast
 	randomNode := make([]byte, 6)
-	if _, err := rand.Read(randomNode); err != nil {
+	if err := readRandom(randomNode); err != nil {
 		return [6]byte{}, fmt.Errorf("fail to generate random node ID: %w", err)
 	}
 	randomNode[0] |= 0x01 // multicast bit
@@ -72,7 +127,7 @@ func GetNodeID() ([6]byte, error) {
 
 func GetRandom14Bit() (uint16, error) {
 	b := make([]byte, 2)
-	if _, err := rand.Read(b); err != nil {
+	if err := readRandom(b); err != nil {
 		return 0, err
 	}
 	return binary.BigEndian.Uint16(b) & clockSeqMask, nil
@@ -80,12 +135,14 @@ func GetRandom14Bit() (uint16, error) {
 
 // timestamp 60-bit in 100 nanoseconds since 1582-10-15 intervals
 func getTimestamp() uint64 {
-	unixTime := time.Now().UnixNano() / 100 // 100-ns intervals
+	unixTime := timeNow().UnixNano() / 100 // 100-ns intervals
 	return uint64(unixTime) + gregorianOffset
 }
 
-// uuid v7 RFC 4122 compliant
-func (g *UUIDv1Generator) NewV1() (string, error) {
+// advance generator state and return the timestamp & clock seq to use
+//
+// `version` is only used to report to the observer
+func (g *UUIDv1Generator) next(version int) (uint64, uint16, error) {
 	g.Mtx.Lock()
 	defer g.Mtx.Unlock()
 
@@ -99,12 +156,15 @@ func (g *UUIDv1Generator) NewV1() (string, error) {
 		// first time init
 		clockSeq, err = GetRandom14Bit()
 		if err != nil {
-			return "", err
+			return 0, 0, err
 		}
 
 	case timestamp < g.LastTimestamp:
 		// clock regression (time backward) - increment clock seq
 		clockSeq = (g.ClockSeq + 1) & clockSeqMask
+		if g.Observer != nil {
+			g.Observer.ClockRegression(version)
+		}
 
 	case timestamp == g.LastTimestamp:
 		// same timestamp - increment clock seq
@@ -112,14 +172,19 @@ func (g *UUIDv1Generator) NewV1() (string, error) {
 		if clockSeq == 0 {
 			// overflow clock seq (16384 uuid in the same 100 nanoseconds)
 			// wait till timestamp changed (RFC 4122:4.2.1.1)
+			start := time.Now()
 			for timestamp == g.LastTimestamp {
 				time.Sleep(time.Microsecond)
 				timestamp = getTimestamp()
 			}
+			if g.Observer != nil {
+				g.Observer.ClockSeqOverflow(version)
+				g.Observer.Waited(version, time.Since(start))
+			}
 			// set clock seq to random val after waited
 			clockSeq, err = GetRandom14Bit()
 			if err != nil {
-				return "", err
+				return 0, 0, err
 			}
 		}
 
@@ -127,7 +192,7 @@ func (g *UUIDv1Generator) NewV1() (string, error) {
 		// forward timestamp - reset clock seq to rand val
 		clockSeq, err = GetRandom14Bit()
 		if err != nil {
-			return "", err
+			return 0, 0, err
 		}
 	}
 
@@ -135,6 +200,20 @@ func (g *UUIDv1Generator) NewV1() (string, error) {
 	g.LastTimestamp = timestamp
 	g.ClockSeq = clockSeq
 
+	if g.Observer != nil {
+		g.Observer.Generated(version)
+	}
+
+	return timestamp, clockSeq, nil
+}
+
+// uuid v1 RFC 4122 compliant
+func (g *UUIDv1Generator) NewV1() (string, error) {
+	timestamp, clockSeq, err := g.next(1)
+	if err != nil {
+		return "", err
+	}
+
 	// uuid v1 (RFC 4122 section 4.2)
 	timeLow := uint32(timestamp & 0xFFFFFFFF)
 	timeMid := uint16((timestamp >> 32) & 0xFFFF)
@@ -157,6 +236,38 @@ func (g *UUIDv1Generator) NewV1() (string, error) {
 	), nil
 }
 
+// uuid v6 RFC 9562 compliant
+//
+// same fields as v1 but the timestamp is stored most significant
+// bits first, so byte order follows generation order
+func (g *UUIDv1Generator) NewV6() (string, error) {
+	timestamp, clockSeq, err := g.next(6)
+	if err != nil {
+		return "", err
+	}
+
+	// uuid v6 (RFC 9562 section 5.6)
+	timeHigh := uint32(timestamp >> 28)
+	timeMid := uint16((timestamp >> 12) & 0xFFFF)
+	timeLowAndVersion := uint16(timestamp&0x0FFF) | 0x6000 // v6
+
+	clockSeqLow := uint8(clockSeq & 0xFF)
+	clockSeqHiAndVariant := uint8((clockSeq>>8)&0x3F) | 0x80 // variant RFC 9562
+
+	// byte array uuid (16 byte)
+	uuid := make([]byte, 16)
+	binary.BigEndian.PutUint32(uuid[0:4], timeHigh)
+	binary.BigEndian.PutUint16(uuid[4:6], timeMid)
+	binary.BigEndian.PutUint16(uuid[6:8], timeLowAndVersion)
+	uuid[8] = clockSeqHiAndVariant
+	uuid[9] = clockSeqLow
+	copy(uuid[10:16], g.Node[:])
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x",
+		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16],
+	), nil
+}
+
 func NewUUIDv1Generator() (*UUIDv1Generator, error) {
 	node, err := GetNodeID()
 	if err != nil {
@@ -178,7 +289,10 @@ func NewUUIDv1Generator() (*UUIDv1Generator, error) {
 
 // generate uuid v1
 func UUIDv1() (UUID, error) {
-	res, _ := UUIDv1asString()
+	res, err := UUIDv1asString()
+	if err != nil {
+		return UUID{}, err
+	}
 	return UUIDfromString(res)
 }
 
@@ -197,9 +311,217 @@ func UUIDv1asString() (string, error) {
 
 // --------------------------------------------------------- //
 
+// DCE security domain of uuid v2 (DCE 1.1 authentication & security services)
+type Domain byte
+
+const (
+	DomainPerson Domain = 0 // POSIX UID
+	DomainGroup  Domain = 1 // POSIX GID
+	DomainOrg    Domain = 2
+)
+
+func (d Domain) String() string {
+	switch d {
+	case DomainPerson:
+		return "person"
+	case DomainGroup:
+		return "group"
+	case DomainOrg:
+		return "org"
+	}
+	return fmt.Sprintf("domain%d", byte(d))
+}
+
+// uuid v2 DCE 1.1 compliant
+//
+// same as v1 but time_low is replaced by local id `id` and
+// clock_seq_low by `domain`, only 6 bit of clock seq remain
+//
+// note: without time_low the timestamp only change every ~7 minutes,
+// the 6 bit are a counter per domain & id in that window, so at most
+// 64 v2 per domain & id, the 65th return an error till the window change
+//
+// params:
+//
+//	domain Domain - DomainPerson, DomainGroup or DomainOrg
+//	id uint32 - local id, e.g. POSIX UID/GID
+//
+// return: string, error - uuid, err||nil
+func (g *UUIDv1Generator) NewV2(domain Domain, id uint32) (string, error) {
+	timestamp, clockSeq, err := g.nextV2(domain, id)
+	if err != nil {
+		return "", err
+	}
+
+	// uuid v2 (DCE 1.1 section 11.5)
+	timeMid := uint16((timestamp >> 32) & 0xFFFF)
+	timeHiAndVersion := uint16((timestamp>>48)&0x0FFF) | 0x2000 // v2
+
+	clockSeqHiAndVariant := (clockSeq & 0x3F) | 0x80 // variant RFC 4122
+
+	// byte array uuid (16 byte)
+	uuid := make([]byte, 16)
+	binary.BigEndian.PutUint32(uuid[0:4], id)
+	binary.BigEndian.PutUint16(uuid[4:6], timeMid)
+	binary.BigEndian.PutUint16(uuid[6:8], timeHiAndVersion)
+	uuid[8] = clockSeqHiAndVariant
+	uuid[9] = byte(domain)
+	copy(uuid[10:16], g.Node[:])
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x",
+		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16],
+	), nil
+}
+
+// get the timestamp & 6-bit clock seq of the next v2 of `domain` & `id`
+//
+// only bit 32..59 of the timestamp are kept, the clock seq start random
+// in each window & count up, error once the 64 values are used
+func (g *UUIDv1Generator) nextV2(domain Domain, id uint32) (uint64, uint8, error) {
+	g.Mtx.Lock()
+	defer g.Mtx.Unlock()
+
+	window := getTimestamp() >> 32
+	switch {
+	case window > g.v2Window || g.v2Seq == nil:
+		// new window, every domain & id start over
+		g.v2Window = window
+		g.v2Seq = make(map[v2Key]*v2Slot)
+	case window < g.v2Window:
+		// clock regression, stay in the last window to not repeat its values
+		if g.Observer != nil {
+			g.Observer.ClockRegression(2)
+		}
+	}
+
+	key := v2Key{domain, id}
+	slot, ok := g.v2Seq[key]
+	if !ok {
+		b := make([]byte, 1)
+		if err := readRandom(b); err != nil {
+			return 0, 0, err
+		}
+		slot = &v2Slot{seq: b[0] & 0x3F}
+		g.v2Seq[key] = slot
+	}
+	if slot.used == 64 {
+		if g.Observer != nil {
+			g.Observer.ClockSeqOverflow(2)
+		}
+		return 0, 0, fmt.Errorf("uuid v2 for %s/%d exhausted, 64 per ~7 minutes", domain, id)
+	}
+	clockSeq := (slot.seq + slot.used) & 0x3F
+	slot.used++
+
+	if g.Observer != nil {
+		g.Observer.Generated(2)
+	}
+
+	return g.v2Window << 32, clockSeq, nil
+}
+
+// generate uuid v2
+//
+// params:
+//
+//	domain Domain - DomainPerson, DomainGroup or DomainOrg
+//	id uint32 - local id
+//
+// return: UUID, err
+func UUIDv2(domain Domain, id uint32) (UUID, error) {
+	res, err := UUIDv2asString(domain, id)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUIDfromString(res)
+}
+
+// generate uuid v2 as string
+//
+// note: v2 share the same generator (clock seq & node) as v1
+//
+// params:
+//
+//	domain Domain - DomainPerson, DomainGroup or DomainOrg
+//	id uint32 - local id
+//
+// return: string, err
+func UUIDv2asString(domain Domain, id uint32) (string, error) {
+	GlobalGeneratorV1Once.Do(func() {
+		GlobalGeneratorV1, GlobalGeneratorV1Err = NewUUIDv1Generator()
+	})
+	if GlobalGeneratorV1Err != nil {
+		return "", fmt.Errorf("fail to initialize uuid v2: %w", GlobalGeneratorV1Err)
+	}
+	return GlobalGeneratorV1.NewV2(domain, id)
+}
+
+// generate uuid v2 for the POSIX UID of current process
+func UUIDv2Person() (UUID, error) {
+	uid := os.Getuid()
+	if uid < 0 {
+		return UUID{}, fmt.Errorf("uid is not available on this platform")
+	}
+	return UUIDv2(DomainPerson, uint32(uid))
+}
+
+// generate uuid v2 for the POSIX GID of current process
+func UUIDv2Group() (UUID, error) {
+	gid := os.Getgid()
+	if gid < 0 {
+		return UUID{}, fmt.Errorf("gid is not available on this platform")
+	}
+	return UUIDv2(DomainGroup, uint32(gid))
+}
+
+// get DCE security domain of uuid v2
+//
+// note: only meaningful when Version() == 2
+func (u UUID) Domain() Domain {
+	return Domain(u[9])
+}
+
+// get local id (e.g. POSIX UID/GID) of uuid v2
+//
+// note: only meaningful when Version() == 2
+func (u UUID) ID() uint32 {
+	return binary.BigEndian.Uint32(u[0:4])
+}
+
+// --------------------------------------------------------- //
+
+// generate uuid v6
+func UUIDv6() (UUID, error) {
+	res, err := UUIDv6asString()
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUIDfromString(res)
+}
+
+// generate uuid v6 as string
+//
+// note: v6 share the same generator (clock seq & node) as v1
+//
+// return: string, err
+func UUIDv6asString() (string, error) {
+	GlobalGeneratorV1Once.Do(func() {
+		GlobalGeneratorV1, GlobalGeneratorV1Err = NewUUIDv1Generator()
+	})
+	if GlobalGeneratorV1Err != nil {
+		return "", fmt.Errorf("fail to initialize uuid v6: %w", GlobalGeneratorV1Err)
+	}
+	return GlobalGeneratorV1.NewV6()
+}
+
+// --------------------------------------------------------- //
+
 // generate uuid v4
 func UUIDv4() (UUID, error) {
-	res, _ := UUIDv4asString()
+	res, err := UUIDv4asString()
+	if err != nil {
+		return UUID{}, err
+	}
 	return UUIDfromString(res)
 }
 
@@ -208,7 +530,7 @@ func UUIDv4() (UUID, error) {
 // return: string, err
 func UUIDv4asString() (string, error) {
 	b := make([]byte, 16)
-	_, err := rand.Read(b)
+	err := readRandom(b)
 	if err != nil {
 		return "uuid_v4-error#1", err
 	}
@@ -227,7 +549,8 @@ func UUIDv4asString() (string, error) {
 type UUIDGeneratorV7 struct {
 	Mtx        sync.Mutex
 	LastMillis int64
-	Counter    uint16 // 12-bit counter (0-4095)
+	Counter    uint16   // 12-bit counter (0-4095)
+	Observer   Observer // optional, nil to disable
 }
 
 var (
@@ -260,7 +583,11 @@ func (g *UUIDGeneratorV7) NewV7() (string, error) {
 	g.Mtx.Lock()
 	defer g.Mtx.Unlock()
 
-	now := time.Now().UnixMilli()
+	now := timeNow().UnixMilli()
+
+	if now < g.LastMillis && g.Observer != nil {
+		g.Observer.ClockRegression(7)
+	}
 
 	// reset counter if millisecond changed
 	if now != g.LastMillis {
@@ -274,12 +601,20 @@ func (g *UUIDGeneratorV7) NewV7() (string, error) {
 		counterBits = g.Counter
 		g.Counter++
 	} else {
-		// overflow use random bits of 12-bit (RFC 9562:6.2)
-		randBuf := make([]byte, 2)
-		if _, err := rand.Read(randBuf); err != nil {
-			return "", err
+		// overflow counter (4095 uuid in the same millisecond)
+		// wait till next millisecond to keep ordering (RFC 9562:6.2)
+		start := time.Now()
+		for now <= g.LastMillis {
+			time.Sleep(10 * time.Microsecond)
+			now = timeNow().UnixMilli()
+		}
+		if g.Observer != nil {
+			g.Observer.CounterOverflow(7)
+			g.Observer.Waited(7, time.Since(start))
 		}
-		counterBits = binary.BigEndian.Uint16(randBuf) & 0x0FFF // get 12 bit
+		g.LastMillis = now
+		counterBits = 0
+		g.Counter = 1
 	}
 
 	// gen uuid v7 RFC 9562 compliant
@@ -294,18 +629,25 @@ func (g *UUIDGeneratorV7) NewV7() (string, error) {
 
 	// 2-bit variant (10) + 62-bit random
 	randBuf := make([]byte, 10)
-	if _, err := rand.Read(randBuf); err != nil {
+	if err := readRandom(randBuf); err != nil {
 		return "", err
 	}
 	uuid[8] = (randBuf[0] & 0x3F) | 0x80 // 10xxxxxx
 	copy(uuid[9:], randBuf[1:])
 
+	if g.Observer != nil {
+		g.Observer.Generated(7)
+	}
+
 	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
 }
 
 // generate uuid v7
 func UUIDv7() (UUID, error) {
-	res, _ := UUIDv7asString()
+	res, err := UUIDv7asString()
+	if err != nil {
+		return UUID{}, err
+	}
 	return UUIDfromString(res)
 }
 
@@ -319,51 +661,136 @@ func UUIDv7asString() (string, error) {
 	if GeneratorV7Err != nil {
 		return "", fmt.Errorf("fail to initialize uuid v7: %w", GeneratorV7Err)
 	}
+	return GeneratorV7.NewV7()
+}
 
-	GeneratorV7.Mtx.Lock()
-	defer GeneratorV7.Mtx.Unlock()
+// generate uuid v7 for time `t` instead of now, rand_a & rand_b random
+//
+// uuid of the same millisecond are unordered between each other, use
+// UUIDGeneratorV7 for monotonic ids of the current time
+//
+// params:
+//
+//	t time.Time - between unix epoch & 2^48 milliseconds later
+//
+// return: UUID, error - uuid v7, err||nil
+func UUIDv7fromTime(t time.Time) (UUID, error) {
+	var uuid UUID
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return uuid, fmt.Errorf("time %s out of uuid v7 range", t.UTC().Format(time.RFC3339))
+	}
 
-	now := time.Now().UnixMilli()
+	if err := readRandom(uuid[6:]); err != nil {
+		return UUID{}, err
+	}
+	PutUint48(uuid[0:6], uint64(ms))
+	uuid[6] = (uuid[6] & 0x0F) | 0x70 // 0111xxxx
+	uuid[8] = (uuid[8] & 0x3F) | 0x80 // 10xxxxxx
+	return uuid, nil
+}
 
-	// reset counter if millisecond changed
-	if now != GeneratorV7.LastMillis {
-		GeneratorV7.LastMillis = now
-		GeneratorV7.Counter = 0
+// get the smallest & largest uuid v7 of the millisecond range [from, to]
+//
+// every v7 generated in the range sort between them in byte order,
+// suitable for `id BETWEEN min AND max` queries
+//
+// params:
+//
+//	from time.Time - first millisecond
+//	to time.Time - last millisecond, not before `from`
+//
+// return: UUID, UUID, error - min, max, err||nil
+func UUIDv7bounds(from, to time.Time) (UUID, UUID, error) {
+	var lo, hi UUID
+	fromMs, toMs := from.UnixMilli(), to.UnixMilli()
+	if fromMs < 0 || toMs >= 1<<48 {
+		return lo, hi, fmt.Errorf("range %s..%s out of uuid v7 range",
+			from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	}
+	if toMs < fromMs {
+		return lo, hi, fmt.Errorf("range end %s before start %s",
+			to.UTC().Format(time.RFC3339Nano), from.UTC().Format(time.RFC3339Nano))
 	}
 
-	var counterBits uint16
-	if GeneratorV7.Counter < 4095 {
-		// inline counter
-		counterBits = GeneratorV7.Counter
-		GeneratorV7.Counter++
-	} else {
-		// overflow use random bits of 12-bit (RFC 9562:6.2)
-		randBuf := make([]byte, 2)
-		if _, err := rand.Read(randBuf); err != nil {
-			return "", err
-		}
-		counterBits = binary.BigEndian.Uint16(randBuf) & 0x0FFF // get 12 bit
+	PutUint48(lo[0:6], uint64(fromMs))
+	lo[6] = 0x70
+	lo[8] = 0x80
+
+	PutUint48(hi[0:6], uint64(toMs))
+	for i := 6; i < len(hi); i++ {
+		hi[i] = 0xFF
 	}
+	hi[6] = 0x7F
+	hi[8] = 0xBF
+	return lo, hi, nil
+}
 
-	// gen uuid v7 RFC 9562 compliant
-	uuid := make([]byte, 16)
+// --------------------------------------------------------- //
 
-	// 48-bit timestamp (unix millisecond)
-	PutUint48(uuid[0:6], uint64(now))
+// well-known namespaces for name-based uuid (RFC 9562 section 6.6)
+var (
+	NamespaceDNS  = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceURL  = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceOID  = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
 
-	// 4-bit version (7) + 12-bit counter/random
-	uuid[6] = (7 << 4) | byte(counterBits>>8) // 0111xxxx
-	uuid[7] = byte(counterBits)
+// hash namespace & name, keep 16 byte and stamp version & variant
+func nameBased(h hash.Hash, ns UUID, name string, version byte) UUID {
+	h.Write(ns[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
 
-	// 2-bit variant (10) + 62-bit random
-	randBuf := make([]byte, 10)
-	if _, err := rand.Read(randBuf); err != nil {
-		return "", err
-	}
-	uuid[8] = (randBuf[0] & 0x3F) | 0x80 // 10xxxxxx
-	copy(uuid[9:], randBuf[1:])
+	var uuid UUID
+	copy(uuid[:], sum)
+	uuid[6] = (uuid[6] & 0x0f) | version<<4 // version
+	uuid[8] = (uuid[8] & 0x3f) | 0x80       // 10xxxxxx
+	return uuid
+}
 
-	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
+// generate name-based uuid v3 (MD5)
+//
+// note: prefer v5, v3 is kept for compatibility
+//
+// params:
+//
+//	ns UUID - namespace, e.g. NamespaceDNS
+//	name string - name within the namespace
+//
+// return: UUID - same input always give the same uuid
+func UUIDv3(ns UUID, name string) UUID {
+	return nameBased(md5.New(), ns, name, 3)
+}
+
+// generate name-based uuid v5 (SHA-1)
+//
+// params:
+//
+//	ns UUID - namespace, e.g. NamespaceDNS
+//	name string - name within the namespace
+//
+// return: UUID - same input always give the same uuid
+func UUIDv5(ns UUID, name string) UUID {
+	return nameBased(sha1.New(), ns, name, 5)
+}
+
+// --------------------------------------------------------- //
+
+// generate custom uuid v8 from 16 byte `b`
+//
+// 122 bit of `b` are kept as is, the version & variant bits are overwritten
+//
+// params:
+//
+//	b [16]byte - custom layout (custom_a 48 bit, custom_b 12 bit, custom_c 62 bit)
+//
+// return: UUID
+func UUIDv8(b [16]byte) UUID {
+	uuid := UUID(b)
+	uuid[6] = (uuid[6] & 0x0f) | 0x80 // 1000xxxx
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // 10xxxxxx
+	return uuid
 }
 
 // --------------------------------------------------------- //
@@ -409,6 +836,9 @@ func UUIDfromString(s string) (UUID, error) {
 	case 36:
 		// ok
 	case 36 + 2:
+		if s[0] != '{' || s[37] != '}' {
+			return uuid, fmt.Errorf("wrong uuid braces: %q %q", s[0], s[37])
+		}
 		s = s[1:]
 	case 36 + 9:
 		if !strings.EqualFold(s[:9], "urn:uuid:") {
@@ -452,9 +882,12 @@ func UUIDfromBytes(b []byte) (UUID, error) {
 	case 36:
 		// ok
 	case 36 + 2:
+		if b[0] != '{' || b[37] != '}' {
+			return uuid, fmt.Errorf("wrong uuid braces: %q %q", b[0], b[37])
+		}
 		b = b[1:]
 	case 36 + 9:
-		if !bytes.EqualFold(b[:9], []byte("urn:uuid")) {
+		if !bytes.EqualFold(b[:9], []byte("urn:uuid:")) {
 			return uuid, fmt.Errorf("wrong urn:prefix: %q", b[:9])
 		}
 		b = b[9:]
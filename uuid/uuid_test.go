@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"net"
+	"os"
 	"regexp"
 	"sync"
 	"testing"
@@ -46,6 +47,24 @@ func TestUUIDv4Format(t *testing.T) {
 	}
 }
 
+// TestUUIDv6Format tests the format of UUID v6
+func TestUUIDv6Format(t *testing.T) {
+	uuid, err := UUIDv6asString()
+	if err != nil {
+		t.Fatalf("UUIDv6() error = %v", err)
+	}
+
+	// RFC 9562 UUID format with version 6
+	pattern := `^[0-9a-f]{8}-[0-9a-f]{4}-6[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+	matched, err := regexp.MatchString(pattern, uuid)
+	if err != nil {
+		t.Fatalf("regex match error: %v", err)
+	}
+	if !matched {
+		t.Errorf("UUID v6 format invalid: %s", uuid)
+	}
+}
+
 // TestUUIDv7Format tests the format of UUID v7
 func TestUUIDv7Format(t *testing.T) {
 	uuid, err := UUIDv7asString()
@@ -363,13 +382,30 @@ func TestUUIDv7CounterOverflow(t *testing.T) {
 		t.Fatalf("Second UUID generation error: %v", err)
 	}
 
-	// Next generation should use random bits since counter overflowed
+	// Next generation should wait for the next millisecond since counter overflowed
 	_, err = g.NewV7()
 	if err != nil {
 		t.Fatalf("Third UUID generation error: %v", err)
 	}
 }
 
+// TestUUIDv7OrderAcrossOverflow tests ordering when more than 4095 uuid share a millisecond
+func TestUUIDv7OrderAcrossOverflow(t *testing.T) {
+	g, _ := NewUUIDGeneratorV7()
+
+	prev := ""
+	for i := 0; i < 20000; i++ {
+		s, err := g.NewV7()
+		if err != nil {
+			t.Fatalf("NewV7() error = %v", err)
+		}
+		if s <= prev {
+			t.Fatalf("NewV7() = %s not after %s at %d", s, prev, i)
+		}
+		prev = s
+	}
+}
+
 // Helper variable for error simulation
 var randRead = func(b []byte) (int, error) {
 	return rand.Read(b)
@@ -613,3 +649,222 @@ func TestCrossVersionUniqueness(t *testing.T) {
 
 	t.Logf("Generated %d unique UUIDs across all versions", len(allUUIDs))
 }
+
+// TestUUIDStringAndVersion tests canonical formatting & version accessor
+func TestUUIDStringAndVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		version int
+	}{
+		{"v1", "C232AB00-9414-11EC-B3C8-9F6BDECED846", "c232ab00-9414-11ec-b3c8-9f6bdeced846", 1},
+		{"v4 braced", "{919108f7-52d1-4320-9bac-f847db4148a8}", "919108f7-52d1-4320-9bac-f847db4148a8", 4},
+		{"v7 hex", "017f22e279b07cc398c4dc0c0c07398f", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7},
+		{"nil", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000000", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UUIDfromString(tt.input)
+			if err != nil {
+				t.Fatalf("UUIDfromString() error = %v", err)
+			}
+			if got := u.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := u.Version(); got != tt.version {
+				t.Errorf("Version() = %d, want %d", got, tt.version)
+			}
+		})
+	}
+}
+
+// TestUUIDv2Format tests the format of UUID v2
+func TestUUIDv2Format(t *testing.T) {
+	uuid, err := UUIDv2asString(DomainPerson, 1000)
+	if err != nil {
+		t.Fatalf("UUIDv2() error = %v", err)
+	}
+
+	// DCE 1.1 UUID format with version 2, domain in clock_seq_low
+	pattern := `^000003e8-[0-9a-f]{4}-2[0-9a-f]{3}-[89ab][0-9a-f]00-[0-9a-f]{12}$`
+	matched, err := regexp.MatchString(pattern, uuid)
+	if err != nil {
+		t.Fatalf("regex match error: %v", err)
+	}
+	if !matched {
+		t.Errorf("UUID v2 format invalid: %s", uuid)
+	}
+}
+
+// TestUUIDv2DomainAndID tests decoding of domain & local id
+func TestUUIDv2DomainAndID(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain Domain
+		id     uint32
+	}{
+		{"person", DomainPerson, 501},
+		{"group", DomainGroup, 20},
+		{"org", DomainOrg, 0xFFFFFFFF},
+		{"zero id", DomainPerson, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UUIDv2(tt.domain, tt.id)
+			if err != nil {
+				t.Fatalf("UUIDv2() error = %v", err)
+			}
+
+			// decode from the parsed string form
+			parsed, err := UUIDfromString(u.String())
+			if err != nil {
+				t.Fatalf("UUIDfromString() error = %v", err)
+			}
+			if parsed.Version() != 2 {
+				t.Errorf("Version() = %d, want 2", parsed.Version())
+			}
+			if parsed.Domain() != tt.domain {
+				t.Errorf("Domain() = %v, want %v", parsed.Domain(), tt.domain)
+			}
+			if parsed.ID() != tt.id {
+				t.Errorf("ID() = %d, want %d", parsed.ID(), tt.id)
+			}
+		})
+	}
+}
+
+// TestUUIDv2Unique tests 64 distinct v2 per domain & id & window, then error
+func TestUUIDv2Unique(t *testing.T) {
+	now := time.Now()
+	withClockAndEntropy(t, now, make([]byte, 64))
+	g := &UUIDv1Generator{}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 64; i++ {
+		s, err := g.NewV2(DomainPerson, 1000)
+		if err != nil {
+			t.Fatalf("NewV2() #%d error = %v", i, err)
+		}
+		if seen[s] {
+			t.Fatalf("NewV2() #%d duplicate %s", i, s)
+		}
+		seen[s] = true
+	}
+	if s, err := g.NewV2(DomainPerson, 1000); err == nil {
+		t.Errorf("NewV2() #65 = %s, want error", s)
+	}
+
+	// other domain & id have their own 64
+	if _, err := g.NewV2(DomainGroup, 1000); err != nil {
+		t.Errorf("NewV2(group) error = %v", err)
+	}
+	if _, err := g.NewV2(DomainPerson, 1001); err != nil {
+		t.Errorf("NewV2(1001) error = %v", err)
+	}
+
+	// next time_mid window start over
+	timeNow = func() time.Time { return now.Add(8 * time.Minute) }
+	s, err := g.NewV2(DomainPerson, 1000)
+	if err != nil {
+		t.Fatalf("NewV2() next window error = %v", err)
+	}
+	if seen[s] {
+		t.Errorf("NewV2() next window duplicate %s", s)
+	}
+}
+
+// TestUUIDv2Person tests v2 from the current process uid & gid
+func TestUUIDv2Person(t *testing.T) {
+	if os.Getuid() < 0 {
+		t.Skip("uid not available on this platform")
+	}
+
+	u, err := UUIDv2Person()
+	if err != nil {
+		t.Fatalf("UUIDv2Person() error = %v", err)
+	}
+	if u.Domain() != DomainPerson || u.ID() != uint32(os.Getuid()) {
+		t.Errorf("UUIDv2Person() = %v/%d, want person/%d", u.Domain(), u.ID(), os.Getuid())
+	}
+
+	g, err := UUIDv2Group()
+	if err != nil {
+		t.Fatalf("UUIDv2Group() error = %v", err)
+	}
+	if g.Domain() != DomainGroup || g.ID() != uint32(os.Getgid()) {
+		t.Errorf("UUIDv2Group() = %v/%d, want group/%d", g.Domain(), g.ID(), os.Getgid())
+	}
+}
+
+// TestDomainString tests domain names
+func TestDomainString(t *testing.T) {
+	tests := []struct {
+		domain Domain
+		want   string
+	}{
+		{DomainPerson, "person"},
+		{DomainGroup, "group"},
+		{DomainOrg, "org"},
+		{Domain(9), "domain9"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.domain.String(); got != tt.want {
+			t.Errorf("Domain(%d).String() = %q, want %q", byte(tt.domain), got, tt.want)
+		}
+	}
+}
+
+// TestUUIDv7fromTime tests the embedded time & layout
+func TestUUIDv7fromTime(t *testing.T) {
+	at := time.Date(2020, 1, 2, 3, 4, 5, 678_000_000, time.UTC)
+	u, err := UUIDv7fromTime(at)
+	if err != nil {
+		t.Fatalf("UUIDv7fromTime() error = %v", err)
+	}
+	if got, _ := u.Time(); !got.Equal(at) {
+		t.Errorf("UUIDv7fromTime() time = %s, want %s", got, at)
+	}
+	if u.Version() != 7 || u.Variant() != VariantRFC9562 {
+		t.Errorf("UUIDv7fromTime() = %s, version %d, variant %s", u, u.Version(), u.Variant())
+	}
+
+	for _, bad := range []time.Time{time.UnixMilli(-1), time.UnixMilli(1 << 48)} {
+		if _, err := UUIDv7fromTime(bad); err == nil {
+			t.Errorf("UUIDv7fromTime(%s) expected error", bad)
+		}
+	}
+}
+
+// TestUUIDv7bounds tests generated uuid fall between the range bounds
+func TestUUIDv7bounds(t *testing.T) {
+	from := time.UnixMilli(1_645_557_742_000)
+	to := from.Add(time.Second)
+
+	lo, hi, err := UUIDv7bounds(from, to)
+	if err != nil {
+		t.Fatalf("UUIDv7bounds() error = %v", err)
+	}
+	if lo.String() != "017f22e2-79b0-7000-8000-000000000000" || hi.String() != "017f22e2-7d98-7fff-bfff-ffffffffffff" {
+		t.Errorf("UUIDv7bounds() = %s, %s", lo, hi)
+	}
+
+	for _, at := range []time.Time{from, from.Add(time.Millisecond), to} {
+		for i := 0; i < 100; i++ {
+			u, _ := UUIDv7fromTime(at)
+			if u.Compare(lo) < 0 || u.Compare(hi) > 0 {
+				t.Fatalf("%s out of [%s, %s]", u, lo, hi)
+			}
+		}
+	}
+	if u, _ := UUIDv7fromTime(to.Add(time.Millisecond)); u.Compare(hi) <= 0 {
+		t.Errorf("%s after range not above %s", u, hi)
+	}
+
+	if _, _, err := UUIDv7bounds(to, from); err == nil {
+		t.Error("UUIDv7bounds() reversed range expected error")
+	}
+}